@@ -34,7 +34,8 @@ func main() {
 	<-make(chan bool)
 }
 
-// evalWrapper wraps the eval function with `syscall/js` parameters
+// evalWrapper wraps the eval function with `syscall/js` parameters. An optional third argument, when truthy, also
+// returns the values of let-bound variables.
 func evalWrapper(_ js.Value, args []js.Value) any {
 	if len(args) < 2 {
 		return response("", errors.New("invalid arguments"))
@@ -46,7 +47,11 @@ func evalWrapper(_ js.Value, args []js.Value) any {
 	if err := yaml.Unmarshal([]byte(is), &input); err != nil {
 		return response("", fmt.Errorf("failed to decode input: %w", err))
 	}
-	output, err := eval.Eval(exp, input)
+	var opts []eval.Option
+	if len(args) > 2 && args[2].Truthy() {
+		opts = append(opts, eval.WithVariables())
+	}
+	output, err := eval.Eval(exp, input, opts...)
 	if err != nil {
 		return response("", err)
 	}
@@ -0,0 +1,96 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/expr-lang/expr/file"
+)
+
+// CheckResponse describes the outcome of type checking an expression without running it.
+type CheckResponse struct {
+	// Valid reports whether the expression compiled.
+	Valid bool `json:"valid"`
+	// Type is the inferred type of the result, or "any" when it is only known at runtime.
+	Type string `json:"type,omitempty"`
+	// EstimatedCost is a rough measure of how expensive the expression is to evaluate, weighting function calls and
+	// loops such as map and filter. It is only meaningful relative to the cost of other expressions.
+	EstimatedCost int `json:"estimatedCost,omitempty"`
+	// Warnings holds non-fatal observations about the expression: those of RunResponse, and a note when the result
+	// type is only known at runtime.
+	Warnings []string `json:"warnings,omitempty"`
+	// Error describes why the expression did not compile.
+	Error *CheckError `json:"error,omitempty"`
+}
+
+// CheckError is a compile error and where in the expression it occurred. Line and Column are 1-based, and are zero
+// when the error has no location.
+type CheckError struct {
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+// Check compiles the expression against the given input, with the same options as Eval, but does not run it. Compile
+// errors are reported in the response rather than returned, so the playground can validate expressions as they are
+// typed.
+func Check(exp string, input map[string]any, opts ...Option) (*CheckResponse, error) {
+	program, warns, err := compile(exp, compileOptions(input), newOptions(opts))
+	if err != nil {
+		checkErr, err := newCheckError(err)
+		if err != nil {
+			return nil, err
+		}
+		return &CheckResponse{Error: checkErr}, nil
+	}
+
+	res := &CheckResponse{
+		Valid:         true,
+		Type:          typeName(program.Node().Type()),
+		EstimatedCost: estimateCost(program.Node()),
+		Warnings:      warns,
+	}
+	if res.Type == "any" {
+		res.Warnings = append(res.Warnings, "the result type is only known at runtime")
+	}
+	return res, nil
+}
+
+// newCheckError returns the CheckError describing a compile error returned by compile. Errors that Expr does not
+// report against the source are returned instead.
+func newCheckError(err error) (*CheckError, error) {
+	var fileErr *file.Error
+	if !errors.As(err, &fileErr) {
+		return nil, err
+	}
+	checkErr := &CheckError{Message: fileErr.Message}
+	if fileErr.Line > 0 {
+		checkErr.Line = fileErr.Line
+		// file.Location columns are 0-based.
+		checkErr.Column = fileErr.Column + 1
+	}
+	return checkErr, nil
+}
+
+// typeName returns a readable name for t, using "any" for unknown and interface types.
+func typeName(t reflect.Type) string {
+	if t == nil || t.Kind() == reflect.Interface {
+		return "any"
+	}
+	return strings.ReplaceAll(t.String(), "interface {}", "any")
+}
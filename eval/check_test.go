@@ -0,0 +1,142 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want *CheckResponse
+	}{
+		{
+			name: "valid",
+			exp:  "object.replicas <= 5",
+			want: &CheckResponse{Valid: true, Type: "bool", EstimatedCost: 5},
+		},
+		{
+			name: "valid map",
+			exp:  "{'replicas': 1}",
+			want: &CheckResponse{Valid: true, Type: "map[string]any", EstimatedCost: 4},
+		},
+		{
+			name: "result only known at runtime",
+			exp:  "object.replicas",
+			want: &CheckResponse{
+				Valid:         true,
+				Type:          "any",
+				EstimatedCost: 3,
+				Warnings:      []string{"the result type is only known at runtime"},
+			},
+		},
+		{
+			name: "type error",
+			exp:  "len(object.image) + 'a'",
+			want: &CheckResponse{
+				Error: &CheckError{
+					Message: "invalid operation: + (mismatched types int and string)",
+					Line:    1,
+					Column:  19,
+				},
+			},
+		},
+		{
+			name: "syntax error with a location",
+			exp:  "object.replicas <= 5 &&\n  (object.image ==",
+			want: &CheckResponse{
+				Error: &CheckError{
+					Message: "unexpected token EOF",
+					Line:    2,
+					Column:  18,
+				},
+			},
+		},
+		{
+			name: "undefined variable",
+			exp:  "missing > 1",
+			want: &CheckResponse{
+				Error: &CheckError{
+					Message: "unknown name missing",
+					Line:    1,
+					Column:  1,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Check(tt.exp, input)
+			if err != nil {
+				t.Fatalf("Check() got error = %v, want %v", err, nil)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Check() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckEstimatedCost(t *testing.T) {
+	// Each expression is expected to cost more than the one before it.
+	exps := []string{
+		"object.replicas <= 5",
+		"len(object.image) <= 5",
+		"all(object.items, # > 1)",
+		"all(map(object.items, # * 2), # > 1)",
+		"all(object.items, any(object.items, # > 1))",
+	}
+
+	prev := 0
+	for _, exp := range exps {
+		got, err := Check(exp, input)
+		if err != nil {
+			t.Fatalf("Check(%q) got error = %v, want %v", exp, err, nil)
+		}
+		if !got.Valid {
+			t.Fatalf("Check(%q) got error = %v, want a valid expression", exp, got.Error)
+		}
+		if got.EstimatedCost <= prev {
+			t.Errorf("Check(%q) got EstimatedCost = %d, want more than %d", exp, got.EstimatedCost, prev)
+		}
+		prev = got.EstimatedCost
+	}
+}
+
+func TestCheckOptions(t *testing.T) {
+	got, err := Check("{1: 'a'}", input, strict())
+	if err != nil {
+		t.Fatalf("Check() got error = %v, want %v", err, nil)
+	}
+	want := &CheckResponse{
+		Error: &CheckError{Message: "map key 1 must be a string, quote it to use it as a key", Line: 1, Column: 2},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Check() in strict mode mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err = Check("missing ?? 1", input, lenient())
+	if err != nil {
+		t.Fatalf("Check() got error = %v, want %v", err, nil)
+	}
+	if !got.Valid {
+		t.Errorf("Check() in lenient mode got error = %v, want a valid expression", got.Error)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import "github.com/expr-lang/expr/ast"
+
+// The weights used by estimateCost. Every node in the AST costs nodeCost, and function calls, including builtins,
+// cost callCost because they do more work than an operator. The body of a loop, such as the predicate passed to map,
+// filter, or all, is assumed to run loopIterations times, so nested loops multiply.
+const (
+	nodeCost       = 1
+	callCost       = 5
+	loopIterations = 10
+)
+
+// estimateCost returns a rough, relative measure of how expensive node is to evaluate. It is derived from the shape of
+// the AST alone, without the input, so it can only be compared against the cost of other expressions.
+func estimateCost(node ast.Node) int {
+	v := &costVisitor{}
+	ast.Walk(&node, v)
+	return v.cost
+}
+
+// costVisitor sums the weighted cost of every node in an AST.
+type costVisitor struct {
+	cost int
+}
+
+func (v *costVisitor) Visit(node *ast.Node) {
+	switch n := (*node).(type) {
+	case *ast.ClosureNode:
+		// The walk has already counted the body once.
+		v.cost += (loopIterations - 1) * estimateCost(n.Node)
+	case *ast.CallNode, *ast.BuiltinNode:
+		v.cost += callCost
+	default:
+		v.cost += nodeCost
+	}
+}
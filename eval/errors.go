@@ -0,0 +1,72 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The phases of evaluation passed to ErrorFormatter.
+const (
+	PhaseCompile = "compile"
+	PhaseRun     = "run"
+	PhaseMarshal = "marshal"
+)
+
+// ErrorFormatter builds the message of each error returned by Eval from the phase that failed, one of PhaseCompile,
+// PhaseRun, or PhaseMarshal, and the underlying error. Embedders can replace it to localize or reshape messages. It is
+// not safe to change while Eval is running, so set it once during initialization. The returned error still wraps the
+// underlying error, so errors.Is and errors.As see through the formatting.
+var ErrorFormatter = DefaultErrorFormatter
+
+// DefaultErrorFormatter is the default ErrorFormatter. It prefixes the error with a description of the failed phase.
+func DefaultErrorFormatter(phase string, err error) string {
+	switch phase {
+	case PhaseCompile:
+		return "failed to compile the Expr expression: " + err.Error()
+	case PhaseRun:
+		return "failed to evaluate: " + err.Error()
+	case PhaseMarshal:
+		return "failed to marshal the output: " + err.Error()
+	}
+	return fmt.Sprintf("failed to %s: %v", phase, err)
+}
+
+// formattedError is an error whose message was built by ErrorFormatter.
+type formattedError struct {
+	msg   string
+	phase string
+	err   error
+}
+
+// formatError returns err with the message ErrorFormatter builds for phase.
+func formatError(phase string, err error) error {
+	return &formattedError{msg: ErrorFormatter(phase, err), phase: phase, err: err}
+}
+
+// prefixError returns err with prefix added to its underlying error, formatting it again for the same phase so that
+// the message keeps the shape ErrorFormatter gives it. Errors that were not formatted are formatted for PhaseRun.
+func prefixError(prefix string, err error) error {
+	var fe *formattedError
+	if errors.As(err, &fe) {
+		return formatError(fe.phase, fmt.Errorf("%s: %w", prefix, fe.err))
+	}
+	return formatError(PhaseRun, fmt.Errorf("%s: %w", prefix, err))
+}
+
+func (e *formattedError) Error() string { return e.msg }
+
+func (e *formattedError) Unwrap() error { return e.err }
@@ -0,0 +1,143 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/expr-lang/expr/file"
+)
+
+func TestErrorFormatter(t *testing.T) {
+	t.Cleanup(func() { ErrorFormatter = DefaultErrorFormatter })
+	ErrorFormatter = func(phase string, err error) string {
+		return "échec (" + phase + ")"
+	}
+
+	// EvalMulti reports each expression's error in the error field of its JSON response.
+	got, err := EvalMulti([]string{"object.", "int(object.image)", "1 + 1"}, input)
+	if err != nil {
+		t.Fatalf("EvalMulti() got error = %v, want %v", err, nil)
+	}
+	out, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("json.Marshal got error = %v, want %v", err, nil)
+	}
+	var res []struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(out, &res); err != nil {
+		t.Fatalf("json.Unmarshal got error = %v, want %v", err, nil)
+	}
+	want := []string{"échec (compile)", "échec (run)", ""}
+	if len(res) != len(want) {
+		t.Fatalf("EvalMulti() returned %d responses, want %d", len(res), len(want))
+	}
+	for i, w := range want {
+		if res[i].Error != w {
+			t.Errorf("EvalMulti()[%d] error = %q, want %q", i, res[i].Error, w)
+		}
+	}
+}
+
+func TestErrorFormatterEntryPoints(t *testing.T) {
+	t.Cleanup(func() { ErrorFormatter = DefaultErrorFormatter })
+	ErrorFormatter = func(phase string, err error) string {
+		return "échec (" + phase + ")"
+	}
+
+	tests := []struct {
+		name string
+		eval func() error
+		want string
+	}{
+		{
+			name: "Eval",
+			eval: func() error {
+				_, err := Eval("object.", input)
+				return err
+			},
+			want: "échec (compile)",
+		},
+		{
+			name: "EvalTyped unknown type",
+			eval: func() error {
+				_, err := EvalTyped("x", map[string]any{"x": 1}, map[string]string{"x": "integer"})
+				return err
+			},
+			want: "échec (compile)",
+		},
+		{
+			name: "EvalTyped input does not match",
+			eval: func() error {
+				_, err := EvalTyped("x", map[string]any{"x": "one"}, map[string]string{"x": "int"})
+				return err
+			},
+			want: "échec (compile)",
+		},
+		{
+			name: "EvalMulti without expressions",
+			eval: func() error {
+				_, err := EvalMulti(nil, input)
+				return err
+			},
+			want: "échec (compile)",
+		},
+		{
+			name: "EvalPipeline without stages",
+			eval: func() error {
+				_, err := EvalPipeline(nil, input)
+				return err
+			},
+			want: "échec (compile)",
+		},
+		{
+			name: "EvalPipeline stage",
+			eval: func() error {
+				_, err := EvalPipeline([]string{"object.image", "int(_)"}, input)
+				return err
+			},
+			want: "échec (run)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.eval()
+			if err == nil {
+				t.Fatalf("got error = nil, want %q", tt.want)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("got error = %q, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultErrorFormatter(t *testing.T) {
+	_, err := Eval("object.", input)
+	if err == nil {
+		t.Fatal("Eval() got error = nil, want a compile error")
+	}
+	if !strings.HasPrefix(err.Error(), "failed to compile the Expr expression: ") {
+		t.Errorf("Eval() error = %q, want the default compile prefix", err)
+	}
+	var fileErr *file.Error
+	if !errors.As(err, &fileErr) {
+		t.Errorf("Eval() error = %T, want it to wrap a *file.Error", err)
+	}
+}
@@ -16,8 +16,11 @@
 package eval
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/expr-lang/expr"
@@ -26,14 +29,106 @@ import (
 )
 
 type RunResponse struct {
-	Result   any         `json:"result"`
+	Result any `json:"result"`
+	// Type is a friendly name for the type of Result: "bool", "int", "float", "string", "duration", "time", "list",
+	// "map", or "null". Other values are named by their Go type.
+	Type string `json:"type,omitempty"`
+	// Bytecode holds the raw opcodes of the compiled program. Disassembly is the readable form of the same program.
 	Bytecode []vm.Opcode `json:"bytecode"`
+	// Disassembly holds one line per instruction of the compiled program, giving its position, the opcode's name, and
+	// its argument, as vm.Program.Disassemble writes it.
+	Disassembly []string `json:"disassembly"`
+	// Variables holds the final value of each let-bound variable. It is only populated when Eval is called with
+	// WithVariables.
+	Variables map[string]any `json:"variables,omitempty"`
+	// Warnings holds non-fatal observations about patterns in the expression that are likely mistakes. They never
+	// prevent evaluation.
+	Warnings []string `json:"warnings,omitempty"`
+	// Error describes why the expression failed. It is only populated by EvalMulti, which reports each expression's
+	// failure in its own response; Eval returns the error instead.
+	Error string `json:"error,omitempty"`
 }
 
 var exprEnvOptions = []expr.Option{
 	expr.AsAny(),
-	// Inject a custom isSorted function into the environment.
+	// Inject the custom functions into the environment.
 	functions.IsSorted(),
+	functions.IsSortedDesc(),
+	functions.Tokenize(),
+	functions.Ngrams(),
+	functions.Entropy(),
+	functions.NormalizeWhitespace(),
+	functions.StripANSI(),
+	functions.Quoting(),
+	functions.Transliterate(),
+	functions.NaturalCompare(),
+	functions.SortNatural(),
+	functions.CharClass(),
+	functions.ControlChars(),
+	functions.RedactRegex(),
+	functions.SplitVariants(),
+	functions.JoinNonEmpty(),
+	functions.FormatList(),
+	functions.Pluralize(),
+	functions.Ordinal(),
+	functions.NumberFormat(),
+	functions.ParseNumber(),
+	functions.Percentile(),
+	functions.Histogram(),
+	functions.MovingAverage(),
+	functions.CumSum(),
+	functions.Normalize(),
+	functions.Divisors(),
+	functions.AdvancedMath(),
+	functions.Combinatorics(),
+	functions.Primes(),
+	functions.Mod(),
+	functions.ToFixed(),
+	functions.SafeDivide(),
+	functions.PercentChange(),
+	functions.WeightedAverage(),
+	functions.Interpolate(),
+	functions.Classify(),
+	functions.DedupeAdjacent(),
+	functions.Rotate(),
+	functions.Windowed(),
+	functions.TakeDrop(),
+	functions.Fold(),
+	functions.FindLast(),
+	functions.Frequencies(),
+	functions.Common(),
+	functions.Match(),
+	functions.KeyBy(),
+	functions.Pluck(),
+	functions.Transpose(),
+	functions.CartesianProduct(),
+	functions.Shuffle(),
+	functions.Sample(),
+	functions.Enumerate(),
+	functions.Compact(),
+	functions.FillMissing(),
+	functions.RenameKeys(),
+	functions.FlattenKeys(),
+	functions.IsWeb3Address(),
+	functions.Wei(),
+	functions.FunctionSelector(),
+	functions.ENSOrAddress(),
+	functions.BigInt(),
+	functions.HexInt(),
+	functions.Gas(),
+	functions.IsChecksummedAddress(),
+	functions.SortedInsert(),
+	functions.BinarySearch(),
+	functions.MergeSorted(),
+	functions.URL(),
+	functions.Quantity(),
+	functions.Sets(),
+	functions.Regex(),
+	functions.ListEquality(),
+	functions.MatchTemplate(),
+	functions.ConformsTo(),
+	functions.RedactPaths(),
+	functions.JSONCanonical(),
 
   // Provide a constant timestamp to the expression environment.
 	expr.DisableBuiltin("now"),
@@ -42,25 +137,175 @@ var exprEnvOptions = []expr.Option{
 	}, new(func() time.Time)),
 }
 
-// Eval evaluates the expr expression against the given input.
-func Eval(exp string, input map[string]any) (string, error) {
-	localOpts := append([]expr.Option{expr.Env(input)}, exprEnvOptions...)
-	program, err := expr.Compile(exp, localOpts...)
-	if err != nil {
-		return "", fmt.Errorf("failed to compile the Expr expression: %w", err)
-	}
-	output, err := expr.Run(program, input)
+// Eval evaluates the expr expression against the given input. Errors are described by ErrorFormatter.
+func Eval(exp string, input map[string]any, opts ...Option) (string, error) {
+	return EvalContext(context.Background(), exp, input, opts...)
+}
+
+// EvalContext evaluates the expr expression against the given input like Eval, but gives up when ctx is done, returning
+// an error that wraps ctx.Err(). The Expr VM cannot be interrupted, so an abandoned evaluation keeps running in the
+// background until it completes; ctx only bounds how long the caller waits for it.
+func EvalContext(ctx context.Context, exp string, input map[string]any, opts ...Option) (string, error) {
+	return eval(ctx, exp, input, input, opts...)
+}
+
+// Diagnostics describes whether an expression compiles. Line and Column are 1-based, and are zero when the error has
+// no location. Warnings are the same as those of RunResponse, and are only reported when the expression compiles.
+type Diagnostics struct {
+	OK       bool     `json:"ok"`
+	Message  string   `json:"message,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Validate compiles the expr expression against the given input, with the same options as Eval, without running it,
+// so expressions can be checked as they are typed. Compile errors are reported in the Diagnostics rather than
+// returned.
+func Validate(exp string, input map[string]any, opts ...Option) (*Diagnostics, error) {
+	_, warns, err := compile(exp, compileOptions(input), newOptions(opts))
 	if err != nil {
-		return "", fmt.Errorf("failed to evaluate: %w", err)
+		checkErr, err := newCheckError(err)
+		if err != nil {
+			return nil, err
+		}
+		return &Diagnostics{Message: checkErr.Message, Line: checkErr.Line, Column: checkErr.Column}, nil
 	}
+	return &Diagnostics{OK: true, Warnings: warns}, nil
+}
 
-	res := &RunResponse{
-		Result:   output,
-		Bytecode: program.Bytecode,
+// eval compiles the expression with variable types taken from env, then runs it against input.
+func eval(ctx context.Context, exp string, env, input map[string]any, opts ...Option) (string, error) {
+	res, err := run(ctx, exp, compileOptions(env), input, newOptions(opts))
+	if err != nil {
+		return "", err
 	}
 	out, err := json.MarshalIndent(res, "", "  ")
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal the output: %w", err)
+		return "", formatError(PhaseMarshal, err)
 	}
 	return string(out), nil
 }
+
+// compileOptions returns the options every expression is compiled with, taking variable types from env.
+func compileOptions(env map[string]any) []expr.Option {
+	return append([]expr.Option{expr.Env(env)}, exprEnvOptions...)
+}
+
+// compile compiles the expression with compileOpts and the compile-time options in o, such as lenient and strict
+// mode, and returns the program along with the warnings about the expression. Errors are described by
+// ErrorFormatter.
+func compile(exp string, compileOpts []expr.Option, o options) (*vm.Program, []string, error) {
+	if o.lenient {
+		// Copy rather than append in place, as callers may reuse compileOpts for other expressions.
+		compileOpts = append(compileOpts[:len(compileOpts):len(compileOpts)], expr.AllowUndefinedVariables())
+	}
+	program, err := expr.Compile(exp, compileOpts...)
+	if err != nil {
+		return nil, nil, formatError(PhaseCompile, err)
+	}
+	if o.strict {
+		if err := checkStrict(exp, program); err != nil {
+			return nil, nil, formatError(PhaseCompile, err)
+		}
+	}
+	return program, warnings(exp), nil
+}
+
+// run compiles the expression with compileOpts and runs it against input, giving up when ctx is done.
+func run(ctx context.Context, exp string, compileOpts []expr.Option, input map[string]any, o options) (*RunResponse, error) {
+	program, warns, err := compile(exp, compileOpts, o)
+	if err != nil {
+		return nil, err
+	}
+	res, err := execute(ctx, program, input, o)
+	if err != nil {
+		return nil, err
+	}
+	res.Warnings = warns
+	return res, nil
+}
+
+// execute runs program against input, giving up when ctx is done.
+func execute(ctx context.Context, program *vm.Program, input map[string]any, o options) (*RunResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, stopped(err)
+	}
+	var machine vm.VM
+	type result struct {
+		output any
+		err    error
+	}
+	// The channel is buffered so the goroutine can finish and exit after run has given up on it.
+	done := make(chan result, 1)
+	go func() {
+		output, err := machine.Run(program, input)
+		done <- result{output, err}
+	}()
+	var output any
+	select {
+	case <-ctx.Done():
+		return nil, stopped(ctx.Err())
+	case r := <-done:
+		if r.err != nil {
+			return nil, formatError(PhaseRun, r.err)
+		}
+		output = r.output
+	}
+
+	res := &RunResponse{
+		Result:      output,
+		Type:        resultType(output),
+		Bytecode:    program.Bytecode,
+		Disassembly: disassembly(program),
+	}
+	if o.variables {
+		res.Variables = letVariables(program, machine.Variables)
+	}
+	return res, nil
+}
+
+// resultType returns the friendly name of the type of v for RunResponse.Type.
+func resultType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case time.Duration:
+		return "duration"
+	case time.Time:
+		return "time"
+	}
+	switch t := reflect.TypeOf(v); t.Kind() {
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "int"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "list"
+	case reflect.Map:
+		return "map"
+	default:
+		return typeName(t)
+	}
+}
+
+// disassembly returns the lines of program.Disassemble, with a single space between columns.
+func disassembly(program *vm.Program) []string {
+	var b strings.Builder
+	program.DisassembleWriter(&b)
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.ReplaceAll(line, "\t", " ")
+	}
+	return lines
+}
+
+// stopped returns the error for an evaluation abandoned because its context is done with err.
+func stopped(err error) error {
+	return formatError(PhaseRun, fmt.Errorf("evaluation stopped: %w", err))
+}
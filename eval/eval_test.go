@@ -16,8 +16,12 @@
 package eval
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/expr-lang/expr"
 	"github.com/google/go-cmp/cmp"
@@ -56,7 +60,6 @@ func TestEval(t *testing.T) {
 			name: "url",
 			exp:  "isURL(object.href) && url(object.href).getScheme() == 'https' && url(object.href).getEscapedPath() == '/path'",
 			want: true,
-			skip: true, // https://github.com/polds/expr-playground/issues/2
 		},
 		{
 			name: "query",
@@ -64,16 +67,20 @@ func TestEval(t *testing.T) {
 			want: map[string]any{
 				"query": []any{"val"},
 			},
-			skip: true, // https://github.com/polds/expr-playground/issues/3
 		},
 		{
 			name: "regex",
 			exp:  "object.image matches 'v[0-9]+.[0-9]+.[0-9]*$'",
 			want: true,
 		},
+		{
+			name: "regex find",
+			exp:  "object.image.find('v[0-9]+.[0-9]+.[0-9]*$')",
+			want: "v0.0.0",
+		},
 		{
 			name: "list",
-			exp:  `isSorted(object.items) && sum(object.items) == 6 && object.items[-1] == 3 && findIndex(object.items, # == 1) == 0`,
+			exp:  `object.items == sort(object.items) && isSorted(object.items) && sum(object.items) == 6 && object.items[-1] == 3 && findIndex(object.items, # == 1) == 0`,
 			want: true,
 		},
 		{
@@ -105,7 +112,6 @@ func TestEval(t *testing.T) {
 			name: "quantity",
 			exp:  `isQuantity(object.memory) && quantity(object.memory).add(quantity("700M")).sub(1).isLessThan(quantity("2G"))`,
 			want: true,
-			skip: true, // https://github.com/polds/expr-playground/issues/9
 		},
 		{
 			name:    "duration",
@@ -116,68 +122,57 @@ func TestEval(t *testing.T) {
 			name: "sets.contains test 1",
 			exp:  `sets.contains([], [])`,
 			want: true,
-			skip: true, // https://github.com/polds/expr-playground/issues/10
 		},
 		{
 			name: "sets.contains test 2",
 			exp:  `sets.contains([], [1])`,
 			want: false,
-			skip: true, // https://github.com/polds/expr-playground/issues/11
 		},
 		{
 			name: "sets.contains test 3",
 			exp:  `sets.contains([1, 2, 3, 4], [2, 3])`,
 			want: true,
-			skip: true, // https://github.com/polds/expr-playground/issues/12
 		},
 		{
 			name: "sets.contains test 4",
 			exp:  `sets.contains([1, 2, 3], [3, 2, 1])`,
 			want: true,
-			skip: true, // https://github.com/polds/expr-playground/issues/13
 		},
 		{
 			name: "sets.equivalent test 1",
 			exp:  `sets.equivalent([], [])`,
 			want: true,
-			skip: true, // https://github.com/polds/expr-playground/issues/14
 		},
 		{
 			name: "sets.equivalent test 2",
 			exp:  `sets.equivalent([1], [1, 1])`,
 			want: true,
-			skip: true, // https://github.com/polds/expr-playground/issues/15
 		},
 		{
 			name: "sets.equivalent test 3",
 			exp:  `sets.equivalent([1], [1, 1])`,
 			want: true,
-			skip: true, // https://github.com/polds/expr-playground/issues/16
 		},
 		{
 			name: "sets.equivalent test 4",
 			exp:  `sets.equivalent([1, 2, 3], [3, 2, 1])`,
 			want: true,
-			skip: true, // https://github.com/polds/expr-playground/issues/17
 		},
 
 		{
 			name: "sets.intersects test 1",
 			exp:  `sets.intersects([1], [])`,
 			want: false,
-			skip: true, // https://github.com/polds/expr-playground/issues/18
 		},
 		{
 			name: "sets.intersects test 2",
 			exp:  `sets.intersects([1], [1, 2])`,
 			want: true,
-			skip: true, // https://github.com/polds/expr-playground/issues/19
 		},
 		{
 			name: "sets.intersects test 3",
 			exp:  `sets.intersects([[1], [2, 3]], [[1, 2], [2, 3]])`,
 			want: true,
-			skip: true, // https://github.com/polds/expr-playground/issues/20
 		},
 	}
 
@@ -380,3 +375,323 @@ func TestValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestEvalWithVariables(t *testing.T) {
+	got, err := Eval(`let total = sum(object.items); let doubled = total * 2; doubled + object.replicas`, input, WithVariables())
+	if err != nil {
+		t.Fatalf("Eval() got error = %v, want %v", err, nil)
+	}
+
+	var res RunResponse
+	if err := json.Unmarshal([]byte(got), &res); err != nil {
+		t.Fatalf("json.Unmarshal got error = %v, want %v", err, nil)
+	}
+	if diff := cmp.Diff(float64(14), res.Result); diff != "" {
+		t.Errorf("Eval() result mismatch (-want +got):\n%s", diff)
+	}
+	want := map[string]any{"total": float64(6), "doubled": float64(12)}
+	if diff := cmp.Diff(want, res.Variables); diff != "" {
+		t.Errorf("Eval() variables mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEvalWithNestedVariables(t *testing.T) {
+	// Variables are numbered in the order they are compiled: y is declared inside the value of x, so it is stored
+	// first, and z, inside the body of x, is stored last.
+	got, err := Eval(`let x = (let y = 2; y * 3); let z = x + 1; [x, z]`, input, WithVariables())
+	if err != nil {
+		t.Fatalf("Eval() got error = %v, want %v", err, nil)
+	}
+
+	var res RunResponse
+	if err := json.Unmarshal([]byte(got), &res); err != nil {
+		t.Fatalf("json.Unmarshal got error = %v, want %v", err, nil)
+	}
+	want := map[string]any{"x": float64(6), "y": float64(2), "z": float64(7)}
+	if diff := cmp.Diff(want, res.Variables); diff != "" {
+		t.Errorf("Eval() variables mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEvalWithVariablesFromPatches(t *testing.T) {
+	// takeWhile is rewritten into builtins with let declarations of its own, which are not the expression's variables.
+	got, err := Eval(`let limit = 3; takeWhile(object.items, "# < limit")`, input, WithVariables())
+	if err != nil {
+		t.Fatalf("Eval() got error = %v, want %v", err, nil)
+	}
+
+	var res RunResponse
+	if err := json.Unmarshal([]byte(got), &res); err != nil {
+		t.Fatalf("json.Unmarshal got error = %v, want %v", err, nil)
+	}
+	want := map[string]any{"limit": float64(3)}
+	if diff := cmp.Diff(want, res.Variables); diff != "" {
+		t.Errorf("Eval() variables mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEvalWithoutVariables(t *testing.T) {
+	got, err := Eval(`let total = sum(object.items); total`, input)
+	if err != nil {
+		t.Fatalf("Eval() got error = %v, want %v", err, nil)
+	}
+
+	var res RunResponse
+	if err := json.Unmarshal([]byte(got), &res); err != nil {
+		t.Fatalf("json.Unmarshal got error = %v, want %v", err, nil)
+	}
+	if res.Variables != nil {
+		t.Errorf("Eval() variables = %v, want none", res.Variables)
+	}
+}
+
+func TestEvalTyped(t *testing.T) {
+	tests := []struct {
+		name    string
+		exp     string
+		input   map[string]any
+		types   map[string]string
+		want    any
+		wantErr bool
+	}{
+		{
+			name:  "typed values",
+			exp:   `replicas > 1 && image startsWith "registry.com"`,
+			input: map[string]any{"replicas": 2, "image": "registry.com/image"},
+			types: map[string]string{"replicas": "int", "image": "string"},
+			want:  true,
+		},
+		{
+			name:  "nil value with a declared type",
+			exp:   `(limit ?? 10) > 5`,
+			input: map[string]any{"limit": nil},
+			types: map[string]string{"limit": "int"},
+			want:  true,
+		},
+		{
+			name:  "integer input for a float",
+			exp:   `ratio / 2`,
+			input: map[string]any{"ratio": 3},
+			types: map[string]string{"ratio": "float"},
+			want:  1.5,
+		},
+		{
+			name:  "typed list",
+			exp:   `all(hosts, # endsWith ".internal")`,
+			input: map[string]any{"hosts": []string{"db.internal"}},
+			types: map[string]string{"hosts": "[]string"},
+			want:  true,
+		},
+		{
+			name:  "decoded list converted to a typed list",
+			exp:   `sum(sizes) > 2`,
+			input: map[string]any{"sizes": []any{1, 2.5}},
+			types: map[string]string{"sizes": "[]float"},
+			want:  true,
+		},
+		{
+			// Untyped, a nil value compares equal to nothing. Typed, the checker rejects the comparison.
+			name:    "comparison caught at compile time",
+			exp:     `name == 5`,
+			input:   map[string]any{"name": nil},
+			types:   map[string]string{"name": "string"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown type name",
+			exp:     `x`,
+			input:   map[string]any{"x": 1},
+			types:   map[string]string{"x": "integer"},
+			wantErr: true,
+		},
+		{
+			name:    "input does not match the declared type",
+			exp:     `x`,
+			input:   map[string]any{"x": "one"},
+			types:   map[string]string{"x": "int"},
+			wantErr: true,
+		},
+		{
+			name:  "integral float input for an int",
+			exp:   `type(x)`,
+			input: map[string]any{"x": 2.0},
+			types: map[string]string{"x": "int"},
+			want:  "int",
+		},
+		{
+			name:    "fractional float input for an int",
+			exp:     `x`,
+			input:   map[string]any{"x": 2.7},
+			types:   map[string]string{"x": "int"},
+			wantErr: true,
+		},
+		{
+			name:    "float input out of range for an int",
+			exp:     `x`,
+			input:   map[string]any{"x": 1e20},
+			types:   map[string]string{"x": "int"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvalTyped(tt.exp, tt.input, tt.types)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EvalTyped() got error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var res RunResponse
+			if err := json.Unmarshal([]byte(got), &res); err != nil {
+				t.Fatalf("json.Unmarshal got error = %v, want %v", err, nil)
+			}
+			if diff := cmp.Diff(tt.want, res.Result); diff != "" {
+				t.Errorf("EvalTyped() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEvalTypedCatchesWhatEvalDefers(t *testing.T) {
+	// Decoded input holds lists as []any, so without declared types the checker cannot see that the elements are
+	// strings and the bad comparison is only found when the expression runs.
+	exp := `tags[0] > 5`
+	input := map[string]any{"tags": []any{"prod", "eu"}}
+
+	if res, err := Check(exp, input); err != nil || !res.Valid {
+		t.Fatalf("Check() got %+v, %v; want the untyped expression to compile", res, err)
+	}
+	if _, err := Eval(exp, input); err == nil || !strings.Contains(err.Error(), "failed to evaluate") {
+		t.Errorf("Eval() got error = %v, want a runtime error", err)
+	}
+	_, err := EvalTyped(exp, input, map[string]string{"tags": "[]string"})
+	if err == nil || !strings.Contains(err.Error(), "failed to compile") {
+		t.Errorf("EvalTyped() got error = %v, want a compile error", err)
+	}
+}
+
+func TestEvalContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	exp := `len(filter(1..900000, # % 7 == 0 || # % 11 == 0 || # % 13 == 0 || # % 17 == 0))`
+	_, err := EvalContext(ctx, exp, input)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("EvalContext() got error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestEvalContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := EvalContext(ctx, "object.replicas <= 5", input)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("EvalContext() got error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestEvalDisassembly(t *testing.T) {
+	got, err := Eval("object.replicas + 2", input)
+	if err != nil {
+		t.Fatalf("Eval() got error = %v, want %v", err, nil)
+	}
+	var res RunResponse
+	if err := json.Unmarshal([]byte(got), &res); err != nil {
+		t.Fatalf("json.Unmarshal got error = %v, want %v", err, nil)
+	}
+	if len(res.Disassembly) == 0 || len(res.Bytecode) != len(res.Disassembly) {
+		t.Fatalf("Eval() got disassembly %q, want one line for each of the %d opcodes", res.Disassembly, len(res.Bytecode))
+	}
+	text := strings.Join(res.Disassembly, "\n")
+	for _, want := range []string{"OpPush", "OpAdd"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Eval() got disassembly %q, want it to contain %s", res.Disassembly, want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		opts []Option
+		want *Diagnostics
+	}{
+		{
+			name: "well formed",
+			exp:  "object.replicas <= 5",
+			want: &Diagnostics{OK: true},
+		},
+		{
+			name: "parse error",
+			exp:  "object.",
+			want: &Diagnostics{Message: "unexpected end of expression", Line: 1, Column: 7},
+		},
+		{
+			name: "type error",
+			exp:  "len(object.image) + 'a'",
+			want: &Diagnostics{Message: "invalid operation: + (mismatched types int and string)", Line: 1, Column: 19},
+		},
+		{
+			name: "strict mode",
+			exp:  "{1: 'a'}",
+			opts: []Option{strict()},
+			want: &Diagnostics{Message: "map key 1 must be a string, quote it to use it as a key", Line: 1, Column: 2},
+		},
+		{
+			name: "lenient mode",
+			exp:  "missing ?? 1",
+			opts: []Option{lenient()},
+			want: &Diagnostics{OK: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Validate(tt.exp, input, tt.opts...)
+			if err != nil {
+				t.Fatalf("Validate() got error = %v, want %v", err, nil)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Validate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEvalResultType(t *testing.T) {
+	tests := []struct {
+		exp  string
+		want string
+	}{
+		{exp: "object.replicas <= 5", want: "bool"},
+		{exp: "join(object.abc, ', ')", want: "string"},
+		{exp: "object.replicas + 1", want: "int"},
+		{exp: "object.replicas / 4", want: "float"},
+		{exp: "object.abc", want: "list"},
+		{exp: "object", want: "map"},
+		{exp: "object?.missing", want: "null"},
+		{exp: "duration('1h')", want: "duration"},
+		{exp: "date('2024-02-26')", want: "time"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.exp, func(t *testing.T) {
+			got, err := Eval(tt.exp, input)
+			if err != nil {
+				t.Fatalf("Eval() got error = %v, want %v", err, nil)
+			}
+			var res RunResponse
+			if err := json.Unmarshal([]byte(got), &res); err != nil {
+				t.Fatalf("json.Unmarshal got error = %v, want %v", err, nil)
+			}
+			if res.Type != tt.want {
+				t.Errorf("Eval() got type %q, want %q", res.Type, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/expr-lang/expr/vm"
+)
+
+// maxExplainSteps caps the number of steps Explain records, so that long-running loops cannot exhaust memory. The
+// program still runs to completion once the cap is reached.
+const maxExplainSteps = 1000
+
+// ErrExplainUnavailable is returned by Explain when the binary was built without the expr_debug build tag, which Expr
+// requires to step through a program one opcode at a time. Use make test-debug and make build-debug to test and build
+// with it.
+var ErrExplainUnavailable = errors.New("explain requires building with the expr_debug tag")
+
+// ExplainResponse is the result of a program along with a trace of how the VM computed it.
+type ExplainResponse struct {
+	Result any    `json:"result"`
+	Steps  []Step `json:"steps"`
+	// Truncated reports whether steps were left out of Steps because the program ran longer than the cap.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Step is a single opcode executed by the VM and the state of its stack around it.
+type Step struct {
+	IP          int    `json:"ip"`
+	Opcode      string `json:"opcode"`
+	Argument    int    `json:"argument"`
+	StackBefore []any  `json:"stackBefore"`
+	StackAfter  []any  `json:"stackAfter"`
+}
+
+// Explain evaluates the expr expression against the given input like Eval, recording each opcode the VM executes so
+// that the playground can replay the evaluation. Stepping through a program is much slower than running it, so this
+// is kept separate from Eval. Errors are described by ErrorFormatter, and still match ErrExplainUnavailable with
+// errors.Is.
+func Explain(exp string, input map[string]any, opts ...Option) (*ExplainResponse, error) {
+	program, _, err := compile(exp, compileOptions(input), newOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	res, err := explain(program, input)
+	if err != nil {
+		return nil, formatError(PhaseRun, err)
+	}
+	return res, nil
+}
+
+// opcodeNames maps each instruction pointer in program to the name of its opcode, taken from the disassembly.
+func opcodeNames(program *vm.Program) map[int]string {
+	names := make(map[int]string)
+	for _, line := range strings.Split(program.Disassemble(), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		if ip, err := strconv.Atoi(fields[0]); err == nil {
+			names[ip] = fields[1]
+		}
+	}
+	return names
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build expr_debug
+
+package eval
+
+import "github.com/expr-lang/expr/vm"
+
+// explain runs program on a debug VM, stepping it one opcode at a time.
+//
+// The VM's stack may only be read while it is paused waiting for the next step, so the stack before each opcode is
+// taken from the one after the previous opcode. After the last opcode the VM does not pause but pops the result and
+// returns, so the final stack is rebuilt from what is left on it and the result.
+func explain(program *vm.Program, input map[string]any) (*ExplainResponse, error) {
+	type result struct {
+		out any
+		err error
+	}
+	machine := vm.Debug()
+	done := make(chan result, 1)
+	go func() {
+		out, err := machine.Run(program, input)
+		done <- result{out, err}
+	}()
+
+	names := opcodeNames(program)
+	res := &ExplainResponse{}
+	record := func(s Step) {
+		if len(res.Steps) < maxExplainSteps {
+			res.Steps = append(res.Steps, s)
+		} else {
+			res.Truncated = true
+		}
+	}
+
+	stack := []any{}
+	var last *Step
+	for ip := 0; ip < len(program.Bytecode); {
+		step := Step{
+			IP:          ip,
+			Opcode:      names[ip],
+			Argument:    program.Arguments[ip],
+			StackBefore: stack,
+		}
+		machine.Step()
+
+		// The VM reports the next instruction pointer once the opcode has run. If the opcode panics, the VM returns
+		// without reporting it.
+		select {
+		case ip = <-machine.Position():
+		case r := <-done:
+			return nil, r.err
+		}
+		if ip >= len(program.Bytecode) {
+			last = &step
+			break
+		}
+		stack = append([]any{}, machine.Stack...)
+		step.StackAfter = stack
+		record(step)
+	}
+
+	r := <-done
+	if r.err != nil {
+		return nil, r.err
+	}
+	if last != nil {
+		last.StackAfter = append(append([]any{}, machine.Stack...), r.out)
+		record(*last)
+	}
+	res.Result = r.out
+	return res, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExplain(t *testing.T) {
+	got, err := Explain("object.replicas * 3 + 1", input)
+	if errors.Is(err, ErrExplainUnavailable) {
+		t.Skip("Explain requires the expr_debug build tag.")
+	}
+	if err != nil {
+		t.Fatalf("Explain() got error = %v, want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(7, got.Result); diff != "" {
+		t.Errorf("Explain() result mismatch (-want +got):\n%s", diff)
+	}
+	var ops []string
+	for _, s := range got.Steps {
+		ops = append(ops, s.Opcode)
+	}
+	wantOps := []string{"OpLoadFast", "OpPush", "OpFetch", "OpDeref", "OpPush", "OpMultiply", "OpDeref", "OpPush", "OpAdd"}
+	if diff := cmp.Diff(wantOps, ops); diff != "" {
+		t.Fatalf("Explain() opcodes mismatch (-want +got):\n%s", diff)
+	}
+
+	multiply := got.Steps[5]
+	if diff := cmp.Diff([]any{2, 3}, multiply.StackBefore); diff != "" {
+		t.Errorf("Explain() stack before OpMultiply mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]any{6}, multiply.StackAfter); diff != "" {
+		t.Errorf("Explain() stack after OpMultiply mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]any{7}, got.Steps[len(got.Steps)-1].StackAfter); diff != "" {
+		t.Errorf("Explain() final stack mismatch (-want +got):\n%s", diff)
+	}
+	if got.Truncated {
+		t.Errorf("Explain() truncated = true, want false")
+	}
+}
+
+func TestExplainRuntimeError(t *testing.T) {
+	_, err := Explain("object.replicas + int(object.image)", input)
+	if errors.Is(err, ErrExplainUnavailable) {
+		t.Skip("Explain requires the expr_debug build tag.")
+	}
+	if err == nil {
+		t.Errorf("Explain() got error = %v, want an error", err)
+	}
+}
+
+func TestExplainTruncated(t *testing.T) {
+	got, err := Explain("sum(map(1..1000, # * 2))", input)
+	if errors.Is(err, ErrExplainUnavailable) {
+		t.Skip("Explain requires the expr_debug build tag.")
+	}
+	if err != nil {
+		t.Fatalf("Explain() got error = %v, want %v", err, nil)
+	}
+	if !got.Truncated || len(got.Steps) != maxExplainSteps {
+		t.Errorf("Explain() recorded %d steps, truncated = %t; want %d steps, truncated", len(got.Steps), got.Truncated, maxExplainSteps)
+	}
+	if diff := cmp.Diff(1001000, got.Result); diff != "" {
+		t.Errorf("Explain() result mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExplainOptions(t *testing.T) {
+	// Options are applied when compiling, before the build tag matters.
+	_, err := Explain("{1: 'a'}", input, strict())
+	if err == nil || errors.Is(err, ErrExplainUnavailable) {
+		t.Errorf("Explain() in strict mode got error = %v, want a compile error", err)
+	}
+}
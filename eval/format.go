@@ -0,0 +1,227 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/parser"
+	"github.com/expr-lang/expr/parser/operator"
+	"github.com/expr-lang/expr/parser/utils"
+)
+
+// errFormatRoundTrip is returned by Format when the formatted expression does not parse back to the same tree.
+var errFormatRoundTrip = errors.New("formatted expression does not parse back to the original expression")
+
+// Format parses the expression and prints it back in Expr's canonical form, with consistent spacing around
+// operators and parentheses only where precedence and associativity require them. Syntax errors are returned with
+// their location. The formatted output is parsed again and compared with the original, so Format never returns an
+// expression that means something different.
+func Format(exp string) (string, error) {
+	tree, err := parser.Parse(exp)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse the Expr expression: %w", err)
+	}
+	out := formatNode(tree.Node)
+
+	again, err := parser.Parse(out)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errFormatRoundTrip, err)
+	}
+	if ast.Dump(again.Node) != ast.Dump(tree.Node) {
+		return "", errFormatRoundTrip
+	}
+	return out, nil
+}
+
+// formatNode prints node as Expr source.
+func formatNode(node ast.Node) string {
+	switch n := node.(type) {
+	case *ast.FloatNode:
+		s := strconv.FormatFloat(n.Value, 'f', -1, 64)
+		if !strings.Contains(s, ".") {
+			s += ".0"
+		}
+		return s
+	case *ast.ConstantNode:
+		if n.Value == nil {
+			return "nil"
+		}
+		b, err := json.Marshal(n.Value)
+		if err != nil {
+			return fmt.Sprintf("%v", n.Value)
+		}
+		return string(b)
+	case *ast.UnaryNode:
+		op := n.Operator
+		if op == "not" {
+			op += " "
+		}
+		return op + formatUnaryOperand(n)
+	case *ast.BinaryNode:
+		lhs := formatNode(n.Left)
+		if needsParens(n.Operator, n.Left, false) {
+			lhs = "(" + lhs + ")"
+		}
+		rhs := formatNode(n.Right)
+		if needsParens(n.Operator, n.Right, true) {
+			rhs = "(" + rhs + ")"
+		}
+		if n.Operator == ".." {
+			return lhs + ".." + rhs
+		}
+		return lhs + " " + n.Operator + " " + rhs
+	case *ast.ChainNode:
+		return formatNode(n.Node)
+	case *ast.MemberNode:
+		if p, ok := n.Node.(*ast.PointerNode); ok && p.Name == "" && !n.Optional {
+			if str, ok := n.Property.(*ast.StringNode); ok && utils.IsValidIdentifier(str.Value) {
+				return "." + str.Value
+			}
+		}
+		base := formatOperand(n.Node)
+		str, ok := n.Property.(*ast.StringNode)
+		switch {
+		case ok && utils.IsValidIdentifier(str.Value) && n.Optional:
+			return base + "?." + str.Value
+		case ok && utils.IsValidIdentifier(str.Value):
+			return base + "." + str.Value
+		case n.Optional:
+			return base + "?.[" + formatNode(n.Property) + "]"
+		default:
+			return base + "[" + formatNode(n.Property) + "]"
+		}
+	case *ast.SliceNode:
+		var from, to string
+		if n.From != nil {
+			from = formatNode(n.From)
+		}
+		if n.To != nil {
+			to = formatNode(n.To)
+		}
+		return formatOperand(n.Node) + "[" + from + ":" + to + "]"
+	case *ast.CallNode:
+		return formatOperand(n.Callee) + "(" + formatList(n.Arguments) + ")"
+	case *ast.BuiltinNode:
+		return n.Name + "(" + formatList(n.Arguments) + ")"
+	case *ast.ClosureNode:
+		return formatNode(n.Node)
+	case *ast.VariableDeclaratorNode:
+		return "let " + n.Name + " = " + formatNode(n.Value) + "; " + formatNode(n.Expr)
+	case *ast.ConditionalNode:
+		return formatConditionalPart(n.Cond) + " ? " + formatConditionalPart(n.Exp1) + " : " +
+			formatConditionalPart(n.Exp2)
+	case *ast.ArrayNode:
+		return "[" + formatList(n.Nodes) + "]"
+	case *ast.MapNode:
+		return "{" + formatList(n.Pairs) + "}"
+	case *ast.PairNode:
+		if str, ok := n.Key.(*ast.StringNode); ok {
+			if utils.IsValidIdentifier(str.Value) {
+				return str.Value + ": " + formatNode(n.Value)
+			}
+			return str.String() + ": " + formatNode(n.Value)
+		}
+		return "(" + formatNode(n.Key) + "): " + formatNode(n.Value)
+	default:
+		// Literals, identifiers and pointers print the same as Expr's own printer.
+		return node.String()
+	}
+}
+
+// formatList prints nodes separated by commas.
+func formatList(nodes []ast.Node) string {
+	out := make([]string, len(nodes))
+	for i, node := range nodes {
+		out[i] = formatNode(node)
+	}
+	return strings.Join(out, ", ")
+}
+
+// formatUnaryOperand prints the operand of a unary operator, wrapping operators so the unary operator applies to the
+// whole operand and never fuses with another sign.
+func formatUnaryOperand(n *ast.UnaryNode) string {
+	switch operand := n.Node.(type) {
+	case *ast.BinaryNode, *ast.ConditionalNode, *ast.VariableDeclaratorNode:
+		return "(" + formatNode(operand) + ")"
+	case *ast.UnaryNode:
+		if operand.Operator == "-" || operand.Operator == "+" {
+			return "(" + formatNode(operand) + ")"
+		}
+	}
+	return formatNode(n.Node)
+}
+
+// formatOperand prints the node a member access, slice or call applies to, wrapping anything that binds looser than
+// the postfix operator.
+func formatOperand(node ast.Node) string {
+	switch node.(type) {
+	case *ast.UnaryNode, *ast.BinaryNode, *ast.ConditionalNode, *ast.VariableDeclaratorNode:
+		return "(" + formatNode(node) + ")"
+	}
+	return formatNode(node)
+}
+
+// formatConditionalPart prints one part of a ternary, wrapping nested ternaries and declarations for readability.
+func formatConditionalPart(node ast.Node) string {
+	switch node.(type) {
+	case *ast.ConditionalNode, *ast.VariableDeclaratorNode:
+		return "(" + formatNode(node) + ")"
+	}
+	return formatNode(node)
+}
+
+// needsParens reports whether child must be wrapped in parentheses to stay the left or right operand of the binary
+// operator op.
+func needsParens(op string, child ast.Node, right bool) bool {
+	parent := operator.Binary[op]
+	switch c := child.(type) {
+	case *ast.ConditionalNode, *ast.VariableDeclaratorNode:
+		return true
+	case *ast.UnaryNode:
+		// Operands on the right are parsed as a unary expression first; on the left, a tighter binary operator
+		// would be parsed into the unary operand.
+		return !right && operator.Unary[c.Operator].Precedence < parent.Precedence
+	case *ast.BinaryNode:
+		if right {
+			break
+		}
+		// Coalescing cannot be mixed with other operators, and chained comparisons like a < b < c parse as
+		// a < b && b < c.
+		if c.Operator == "??" && op != "??" {
+			return true
+		}
+		if operator.IsComparison(c.Operator) && operator.IsComparison(op) {
+			return true
+		}
+	default:
+		return false
+	}
+
+	c := child.(*ast.BinaryNode)
+	precedence := operator.Binary[c.Operator].Precedence
+	if precedence != parent.Precedence {
+		return precedence < parent.Precedence
+	}
+	if right {
+		return parent.Associativity == operator.Left
+	}
+	return parent.Associativity == operator.Right
+}
@@ -0,0 +1,148 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/expr-lang/expr/file"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want string
+	}{
+		{
+			name: "operator spacing",
+			exp:  "1+2 *3",
+			want: "1 + 2 * 3",
+		},
+		{
+			name: "required parentheses are kept",
+			exp:  "( 1+2 )*3",
+			want: "(1 + 2) * 3",
+		},
+		{
+			name: "member access and logical operators",
+			exp:  "object.replicas<=5&&foo",
+			want: "object.replicas <= 5 && foo",
+		},
+		{
+			name: "strings use double quotes",
+			exp:  "object?.foo ?? 'x'",
+			want: `object?.foo ?? "x"`,
+		},
+		{
+			name: "let and ternary",
+			exp:  "let x=1;x>0?x:-x",
+			want: "let x = 1; x > 0 ? x : -x",
+		},
+		{
+			name: "pipes become calls",
+			exp:  "object.image|split(':')",
+			want: `split(object.image, ":")`,
+		},
+		{
+			name: "right operand of a left-associative operator",
+			exp:  "1-(2-3)",
+			want: "1 - (2 - 3)",
+		},
+		{
+			name: "division is left-associative",
+			exp:  "10/(5/5)",
+			want: "10 / (5 / 5)",
+		},
+		{
+			name: "left operand of a right-associative operator",
+			exp:  "(2**3)**2",
+			want: "(2 ** 3) ** 2",
+		},
+		{
+			name: "right-associative operator needs no parentheses",
+			exp:  "2**(3**2)",
+			want: "2 ** 3 ** 2",
+		},
+		{
+			name: "ternary as an operand",
+			exp:  "(true?1:2)+10",
+			want: "(true ? 1 : 2) + 10",
+		},
+		{
+			name: "unary operand of a tighter operator",
+			exp:  "(-2)**2",
+			want: "(-2) ** 2",
+		},
+		{
+			name: "coalescing cannot be mixed",
+			exp:  "(a??1)+2",
+			want: "(a ?? 1) + 2",
+		},
+		{
+			name: "comparisons are not chained",
+			exp:  "(a<b)<c",
+			want: "(a < b) < c",
+		},
+		{
+			name: "member access on an operator",
+			exp:  "(a+b).c",
+			want: "(a + b).c",
+		},
+		{
+			name: "floats keep their decimal point",
+			exp:  "1.0+x",
+			want: "1.0 + x",
+		},
+		{
+			name: "map keys that are not identifiers",
+			exp:  `{"a-b":1,c:2}`,
+			want: `{"a-b": 1, c: 2}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Format(tt.exp)
+			if err != nil {
+				t.Fatalf("Format() got error = %v, want %v", err, nil)
+			}
+			if got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+
+			// Formatting is idempotent.
+			again, err := Format(got)
+			if err != nil {
+				t.Fatalf("Format() of formatted output got error = %v, want %v", err, nil)
+			}
+			if again != got {
+				t.Errorf("Format() of formatted output = %q, want %q", again, got)
+			}
+		})
+	}
+}
+
+func TestFormatSyntaxError(t *testing.T) {
+	_, err := Format("object.replicas <=")
+	var fileErr *file.Error
+	if !errors.As(err, &fileErr) {
+		t.Fatalf("Format() got error = %v, want a *file.Error", err)
+	}
+	if fileErr.Line != 1 || fileErr.Column != 17 {
+		t.Errorf("Format() error at %d:%d, want 1:17", fileErr.Line, fileErr.Column)
+	}
+}
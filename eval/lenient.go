@@ -0,0 +1,32 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import "context"
+
+// EvalLenient evaluates the expr expression against the given input like Eval, but treats variables that are not in
+// the input as nil instead of failing to compile. Eval rejects an expression that names a variable the input does not
+// have, which catches typos before anything runs; EvalLenient trades that check for the ability to explore an input
+// whose shape is not yet known. Fields of variables that are in the input behave the same in both modes.
+func EvalLenient(exp string, input map[string]any, opts ...Option) (string, error) {
+	return eval(context.Background(), exp, input, input, append(opts, lenient())...)
+}
+
+// lenient enables the undefined variable handling of EvalLenient.
+func lenient() Option {
+	return func(o *options) {
+		o.lenient = true
+	}
+}
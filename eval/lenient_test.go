@@ -0,0 +1,73 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEvalLenient(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want any
+	}{
+		{
+			name: "missing field with a fallback",
+			exp:  `object?.missing ?? "x"`,
+			want: "x",
+		},
+		{
+			name: "undefined variable",
+			exp:  "undefinedVariable",
+			want: nil,
+		},
+		{
+			name: "undefined variable with a fallback",
+			exp:  `undefinedVariable ?? object.replicas`,
+			want: 2.0,
+		},
+		{
+			name: "defined variables are unaffected",
+			exp:  "object.replicas <= 5",
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvalLenient(tt.exp, input)
+			if err != nil {
+				t.Fatalf("EvalLenient() got error = %v, want %v", err, nil)
+			}
+			var res RunResponse
+			if err := json.Unmarshal([]byte(got), &res); err != nil {
+				t.Fatalf("json.Unmarshal got error = %v, want %v", err, nil)
+			}
+			if diff := cmp.Diff(tt.want, res.Result); diff != "" {
+				t.Errorf("EvalLenient() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEvalRejectsUndefinedVariables(t *testing.T) {
+	if _, err := Eval("undefinedVariable", input); err == nil {
+		t.Errorf("Eval() got error = %v, want a compile error", err)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"context"
+	"errors"
+)
+
+// EvalMulti evaluates each expression against the same input, returning one response per expression in the same
+// order. The expressions are independent: one that fails to compile or run does not stop the others, and its
+// response holds the error message in Error rather than a result. An error is only returned when there are no
+// expressions to evaluate.
+func EvalMulti(exprs []string, input map[string]any, opts ...Option) ([]RunResponse, error) {
+	if len(exprs) == 0 {
+		return nil, formatError(PhaseCompile, errors.New("no expressions to evaluate"))
+	}
+
+	o := newOptions(opts)
+	compileOpts := compileOptions(input)
+	out := make([]RunResponse, len(exprs))
+	for i, exp := range exprs {
+		res, err := run(context.Background(), exp, compileOpts, input, o)
+		if err != nil {
+			out[i].Error = err.Error()
+			continue
+		}
+		out[i] = *res
+	}
+	return out, nil
+}
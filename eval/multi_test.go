@@ -0,0 +1,75 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEvalMulti(t *testing.T) {
+	exprs := []string{
+		"object.replicas <= 5",
+		"object.",
+		"join(object.abc, ', ')",
+		"int(object.image)",
+		"object.replicas > 5",
+	}
+	got, err := EvalMulti(exprs, input)
+	if err != nil {
+		t.Fatalf("EvalMulti() got error = %v, want %v", err, nil)
+	}
+	if len(got) != len(exprs) {
+		t.Fatalf("EvalMulti() returned %d responses, want %d", len(got), len(exprs))
+	}
+
+	want := []struct {
+		result any
+		err    string
+	}{
+		{result: true},
+		{err: "failed to compile the Expr expression"},
+		{result: "a, b, c"},
+		{err: "failed to evaluate"},
+		{result: false},
+	}
+	for i, w := range want {
+		res := got[i]
+		if w.err != "" {
+			if !strings.HasPrefix(res.Error, w.err) {
+				t.Errorf("EvalMulti()[%d].Error = %q, want prefix %q", i, res.Error, w.err)
+			}
+			if res.Result != nil {
+				t.Errorf("EvalMulti()[%d].Result = %v, want nil", i, res.Result)
+			}
+			continue
+		}
+		if res.Error != "" {
+			t.Errorf("EvalMulti()[%d].Error = %q, want none", i, res.Error)
+		}
+		if res.Result != w.result {
+			t.Errorf("EvalMulti()[%d].Result = %v, want %v", i, res.Result, w.result)
+		}
+		if len(res.Bytecode) == 0 {
+			t.Errorf("EvalMulti()[%d].Bytecode is empty", i)
+		}
+	}
+}
+
+func TestEvalMultiEmpty(t *testing.T) {
+	if _, err := EvalMulti(nil, input); err == nil {
+		t.Error("EvalMulti() got error = nil, want an error")
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+// Option configures optional behavior of Eval.
+type Option func(*options)
+
+type options struct {
+	variables bool
+	strict    bool
+	lenient   bool
+}
+
+// newOptions applies opts to the default options.
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithVariables captures the final value of each let-bound variable into RunResponse.Variables.
+func WithVariables() Option {
+	return func(o *options) {
+		o.variables = true
+	}
+}
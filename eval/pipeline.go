@@ -0,0 +1,56 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// EvalPipeline evaluates the expressions in stages one after the other against the given input, passing the result
+// of each stage to the next as the variable _. The response is that of the last stage. The first stage sees only
+// the input, and later stages see the input alongside _, which hides any input variable of the same name. If a stage
+// fails, the pipeline stops and the error names the stage, counting from 1. Errors are described by ErrorFormatter.
+func EvalPipeline(stages []string, input map[string]any, opts ...Option) (string, error) {
+	if len(stages) == 0 {
+		return "", formatError(PhaseCompile, errors.New("no stages to evaluate"))
+	}
+
+	o := newOptions(opts)
+	env := input
+	var res *RunResponse
+	for i, exp := range stages {
+		if i > 0 {
+			env = make(map[string]any, len(input)+1)
+			for k, v := range input {
+				env[k] = v
+			}
+			env["_"] = res.Result
+		}
+		var err error
+		res, err = run(context.Background(), exp, compileOptions(env), env, o)
+		if err != nil {
+			return "", prefixError(fmt.Sprintf("stage %d", i+1), err)
+		}
+	}
+
+	out, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return "", formatError(PhaseMarshal, err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEvalPipeline(t *testing.T) {
+	tests := []struct {
+		name    string
+		stages  []string
+		want    any
+		wantErr string
+	}{
+		{
+			name: "three stages",
+			stages: []string{
+				"map(object.items, # * object.replicas)",
+				"filter(_, # > 2)",
+				"sum(_)",
+			},
+			want: float64(10),
+		},
+		{
+			name:   "single stage",
+			stages: []string{"object.replicas"},
+			want:   float64(2),
+		},
+		{
+			name: "stage result changes type",
+			stages: []string{
+				"split(object.image, ':')",
+				"_[1]",
+				"_ == 'v0.0.0'",
+			},
+			want: true,
+		},
+		{
+			name: "mid-pipeline compile error",
+			stages: []string{
+				"object.items",
+				"_ +",
+				"len(_)",
+			},
+			wantErr: "failed to compile the Expr expression: stage 2: ",
+		},
+		{
+			name: "mid-pipeline runtime error",
+			stages: []string{
+				"object.image",
+				"int(_)",
+				"_ * 2",
+			},
+			wantErr: "failed to evaluate: stage 2: ",
+		},
+		{
+			name:    "no stages",
+			wantErr: "failed to compile the Expr expression: no stages to evaluate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvalPipeline(tt.stages, input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+					t.Fatalf("EvalPipeline() got error = %v, want prefix %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EvalPipeline() got error = %v, want %v", err, nil)
+			}
+
+			var res RunResponse
+			if err := json.Unmarshal([]byte(got), &res); err != nil {
+				t.Fatalf("json.Unmarshal got error = %v, want %v", err, nil)
+			}
+			if diff := cmp.Diff(tt.want, res.Result); diff != "" {
+				t.Errorf("EvalPipeline() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EvalPretty evaluates the expr expression against the given input like Eval, but renders the result for people
+// rather than as JSON. Durations are spelled out, as in "1 hour 30 minutes", times are formatted as RFC 3339, and
+// lists and maps are written one element per line, with nested lists and maps indented beneath their parent.
+func EvalPretty(exp string, input map[string]any, opts ...Option) (string, error) {
+	res, err := run(context.Background(), exp, compileOptions(input), input, newOptions(opts))
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	writePretty(&b, res.Result, "")
+	return b.String(), nil
+}
+
+// writePretty writes v to b. Lists and maps are written one element per line, with each line prefixed by indent.
+func writePretty(b *strings.Builder, v any, indent string) {
+	rv := reflect.ValueOf(v)
+	switch {
+	case v == nil:
+		b.WriteString("nil")
+	case rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array:
+		if rv.Len() == 0 {
+			b.WriteString("[]")
+			return
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(indent + "-")
+			writeNested(b, rv.Index(i).Interface(), indent)
+		}
+	case rv.Kind() == reflect.Map:
+		if rv.Len() == 0 {
+			b.WriteString("{}")
+			return
+		}
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for i, k := range keys {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(b, "%s%v:", indent, k.Interface())
+			writeNested(b, rv.MapIndex(k).Interface(), indent)
+		}
+	default:
+		b.WriteString(prettyScalar(v))
+	}
+}
+
+// writeNested writes an element of a list or map after its "-" or key. Non-empty lists and maps start on the next
+// line, indented one level deeper than their parent, and anything else follows on the same line.
+func writeNested(b *strings.Builder, v any, indent string) {
+	rv := reflect.ValueOf(v)
+	if v != nil && (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array || rv.Kind() == reflect.Map) && rv.Len() > 0 {
+		b.WriteString("\n")
+		writePretty(b, v, indent+"  ")
+		return
+	}
+	b.WriteString(" ")
+	writePretty(b, v, indent)
+}
+
+// prettyScalar renders a value that is not a list or map.
+func prettyScalar(v any) string {
+	switch t := v.(type) {
+	case bool:
+		return strconv.FormatBool(t)
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(t), 'f', -1, 32)
+	case time.Duration:
+		return humanizeDuration(t)
+	case time.Time:
+		return t.Format(time.RFC3339)
+	}
+	return fmt.Sprint(v)
+}
+
+// durationUnits are the units humanizeDuration spells a duration out in, largest first.
+var durationUnits = []struct {
+	name string
+	size time.Duration
+}{
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+	{"millisecond", time.Millisecond},
+	{"microsecond", time.Microsecond},
+	{"nanosecond", time.Nanosecond},
+}
+
+// humanizeDuration spells d out in words, such as "1 hour 30 minutes", leaving out units that are zero.
+func humanizeDuration(d time.Duration) string {
+	if d == 0 {
+		return "0 seconds"
+	}
+	var parts []string
+	if d < 0 {
+		parts = append(parts, "minus")
+	}
+	// Work with the magnitude as a uint64, since the most negative duration cannot be negated.
+	rest := uint64(d)
+	if d < 0 {
+		rest = -rest
+	}
+	for _, u := range durationUnits {
+		n := rest / uint64(u.size)
+		rest %= uint64(u.size)
+		switch {
+		case n == 1:
+			parts = append(parts, "1 "+u.name)
+		case n > 1:
+			parts = append(parts, strconv.FormatUint(n, 10)+" "+u.name+"s")
+		}
+	}
+	return strings.Join(parts, " ")
+}
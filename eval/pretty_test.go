@@ -0,0 +1,132 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import "testing"
+
+func TestEvalPretty(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want string
+	}{
+		{
+			name: "true",
+			exp:  "object.replicas <= 5",
+			want: "true",
+		},
+		{
+			name: "false",
+			exp:  "object.replicas > 5",
+			want: "false",
+		},
+		{
+			name: "nil",
+			exp:  "object?.missing",
+			want: "nil",
+		},
+		{
+			name: "string",
+			exp:  "object.image",
+			want: "registry.com/image:v0.0.0",
+		},
+		{
+			name: "int",
+			exp:  "object.replicas * 1000000",
+			want: "2000000",
+		},
+		{
+			name: "float",
+			exp:  "object.replicas / 4",
+			want: "0.5",
+		},
+		{
+			name: "duration",
+			exp:  "duration('1h30m')",
+			want: "1 hour 30 minutes",
+		},
+		{
+			name: "duration with small units",
+			exp:  "duration('2h3m4s5us')",
+			want: "2 hours 3 minutes 4 seconds 5 microseconds",
+		},
+		{
+			name: "negative duration",
+			exp:  "duration('-1s')",
+			want: "minus 1 second",
+		},
+		{
+			name: "zero duration",
+			exp:  "duration('0s')",
+			want: "0 seconds",
+		},
+		{
+			name: "time",
+			exp:  "date('2024-02-26T15:04:05+01:00')",
+			want: "2024-02-26T15:04:05+01:00",
+		},
+		{
+			name: "list",
+			exp:  "object.abc",
+			want: "- a\n- b\n- c",
+		},
+		{
+			name: "empty list",
+			exp:  "[]",
+			want: "[]",
+		},
+		{
+			name: "map with sorted keys",
+			exp:  "{'replicas': object.replicas, 'enabled': true}",
+			want: "enabled: true\nreplicas: 2",
+		},
+		{
+			name: "nested",
+			exp:  "{'items': [1, [2, 3]], 'meta': {'name': 'x', 'tags': []}, 'ttl': duration('90s')}",
+			want: "items:\n  - 1\n  -\n    - 2\n    - 3\nmeta:\n  name: x\n  tags: []\nttl: 1 minute 30 seconds",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvalPretty(tt.exp, input)
+			if err != nil {
+				t.Fatalf("EvalPretty() got error = %v, want %v", err, nil)
+			}
+			if got != tt.want {
+				t.Errorf("EvalPretty() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvalPrettyError(t *testing.T) {
+	if _, err := EvalPretty("object.", input); err == nil {
+		t.Error("EvalPretty() got error = nil, want a compile error")
+	}
+}
+
+func TestEvalPrettyOptions(t *testing.T) {
+	if _, err := EvalPretty("{1: 'a'}", input, strict()); err == nil {
+		t.Error("EvalPretty() in strict mode got error = nil, want a compile error")
+	}
+	got, err := EvalPretty("missing ?? 'default'", input, lenient())
+	if err != nil {
+		t.Fatalf("EvalPretty() in lenient mode got error = %v, want %v", err, nil)
+	}
+	if got != "default" {
+		t.Errorf("EvalPretty() in lenient mode = %q, want %q", got, "default")
+	}
+}
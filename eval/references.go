@@ -0,0 +1,173 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/parser"
+	"github.com/polds/expr-playground/functions"
+)
+
+// ReferencedVariables parses the expression and returns the sorted names of the variables it reads from its input.
+// Names bound by let, closure parameters such as #, and the names of called functions are not variables of the
+// input, so they are left out. The closure bodies given as string literals to functions such as takeWhile are parsed
+// and searched as well.
+func ReferencedVariables(exp string) ([]string, error) {
+	tree, err := parseReferences(exp)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &identifierCollector{}
+	ast.Walk(&tree.Node, c)
+
+	// An identifier is bound when it is inside the body of a let that declares its name. The value of a let is not
+	// part of its body, so let x = x; x still reads x from the input.
+	bound := make(map[*ast.IdentifierNode]bool)
+	for _, decl := range c.lets {
+		body := &identifierCollector{}
+		ast.Walk(&decl.Expr, body)
+		for _, id := range body.identifiers {
+			if id.Value == decl.Name {
+				bound[id] = true
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	names := []string{}
+	for _, id := range c.identifiers {
+		if bound[id] || c.callees[id] || id.Value == "$env" || seen[id.Value] {
+			continue
+		}
+		seen[id.Value] = true
+		names = append(names, id.Value)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// UsedFunctions parses the expression and returns the sorted, distinct names of the functions it calls, both
+// builtins and custom functions. Calls written with a pipe, as in x | split(":"), are included, and so are calls in the
+// sets namespace, as in sets.contains(a, b), and the find and findAll methods, as in s.find(pattern), which are
+// compiled into function calls. Other methods called on a value, as in url(s).getHost(), are not functions and are
+// left out.
+func UsedFunctions(exp string) ([]string, error) {
+	tree, err := parseReferences(exp)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &identifierCollector{}
+	ast.Walk(&tree.Node, c)
+
+	seen := make(map[string]bool)
+	names := []string{}
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, name := range c.functions {
+		add(name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// parseReferences parses the expression, replacing each closure body given as a string literal with the parsed
+// closure so that its identifiers and calls are searched along with the rest of the expression.
+func parseReferences(exp string) (*parser.Tree, error) {
+	tree, err := parser.Parse(exp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the Expr expression: %w", err)
+	}
+	ast.Walk(&tree.Node, stringClosureExpander{})
+	return tree, nil
+}
+
+// stringClosureExpander replaces the string literal closure bodies of the calls it visits with their parsed closures.
+type stringClosureExpander struct{}
+
+func (e stringClosureExpander) Visit(node *ast.Node) {
+	call, ok := (*node).(*ast.CallNode)
+	if !ok {
+		return
+	}
+	closure, ok := functions.StringClosure(call)
+	if !ok {
+		return
+	}
+	// Walk visits children first, so closures nested in the body are expanded here.
+	var body ast.Node = closure
+	ast.Walk(&body, e)
+	call.Arguments[len(call.Arguments)-1] = closure
+}
+
+// identifierCollector collects the identifiers, let declarations, and called function names in an AST.
+type identifierCollector struct {
+	identifiers []*ast.IdentifierNode
+	lets        []*ast.VariableDeclaratorNode
+	// callees holds the identifiers that name a called function or namespace rather than a variable.
+	callees   map[*ast.IdentifierNode]bool
+	functions []string
+}
+
+func (c *identifierCollector) Visit(node *ast.Node) {
+	switch n := (*node).(type) {
+	case *ast.IdentifierNode:
+		c.identifiers = append(c.identifiers, n)
+	case *ast.VariableDeclaratorNode:
+		c.lets = append(c.lets, n)
+	case *ast.BuiltinNode:
+		c.functions = append(c.functions, n.Name)
+	case *ast.CallNode:
+		switch callee := n.Callee.(type) {
+		case *ast.IdentifierNode:
+			c.call(callee, callee.Value)
+		case *ast.MemberNode:
+			c.method(callee, len(n.Arguments))
+		}
+	}
+}
+
+// call records a call to the function name, whose callee is id.
+func (c *identifierCollector) call(id *ast.IdentifierNode, name string) {
+	if c.callees == nil {
+		c.callees = make(map[*ast.IdentifierNode]bool)
+	}
+	c.callees[id] = true
+	c.functions = append(c.functions, name)
+}
+
+// method records the calls written as methods that functions.Sets and functions.Regex patch into function calls:
+// sets.name(a, b), which calls the function sets.name, and s.find(pattern) and s.findAll(pattern).
+func (c *identifierCollector) method(member *ast.MemberNode, args int) {
+	property, ok := member.Property.(*ast.StringNode)
+	if !ok || member.Optional {
+		return
+	}
+	if id, ok := member.Node.(*ast.IdentifierNode); ok && id.Value == "sets" {
+		c.call(id, "sets."+property.Value)
+		return
+	}
+	if (property.Value == "find" || property.Value == "findAll") && args == 1 {
+		c.functions = append(c.functions, property.Value)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReferencedVariables(t *testing.T) {
+	tests := []struct {
+		name    string
+		exp     string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "members and identifiers",
+			exp:  "object.replicas <= 5 && foo",
+			want: []string{"foo", "object"},
+		},
+		{
+			name: "duplicates",
+			exp:  "a + a * b",
+			want: []string{"a", "b"},
+		},
+		{
+			name: "closure parameter does not leak",
+			exp:  "all(items, # > threshold) && any(map(items, #), {# == 1})",
+			want: []string{"items", "threshold"},
+		},
+		{
+			name: "let bindings are not inputs",
+			exp:  "let total = sum(object.items); total > limit",
+			want: []string{"limit", "object"},
+		},
+		{
+			name: "let value reads the input",
+			exp:  "let x = x + 1; x",
+			want: []string{"x"},
+		},
+		{
+			name: "function names are not variables",
+			exp:  "isSorted(object.items) && join(object.abc, sep) != ''",
+			want: []string{"object", "sep"},
+		},
+		{
+			name: "string closure bodies",
+			exp:  "takeWhile(xs, '# < limit') == fold(ys, 0, 'let step = #acc + #; step * factor')",
+			want: []string{"factor", "limit", "xs", "ys"},
+		},
+		{
+			name: "nested string closure bodies",
+			exp:  `takeWhile(rows, 'allMatch(#, "# < limit")')`,
+			want: []string{"limit", "rows"},
+		},
+		{
+			name: "string closure bound by an outer let",
+			exp:  "let limit = 3; anyMatch(xs, '# > limit')",
+			want: []string{"xs"},
+		},
+		{
+			name: "sets namespace is not a variable",
+			exp:  "sets.contains(a, b) && sets.intersects(a, object.items)",
+			want: []string{"a", "b", "object"},
+		},
+		{
+			name: "regex methods",
+			exp:  "object.image.find(pattern) != '' && len(tag.findAll('[0-9]+')) > 1",
+			want: []string{"object", "pattern", "tag"},
+		},
+		{
+			name: "computed member",
+			exp:  "object[key]",
+			want: []string{"key", "object"},
+		},
+		{
+			name: "no variables",
+			exp:  "1 + 2",
+			want: []string{},
+		},
+		{
+			name:    "syntax error",
+			exp:     "object.",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReferencedVariables(tt.exp)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReferencedVariables() got error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ReferencedVariables() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUsedFunctions(t *testing.T) {
+	tests := []struct {
+		name    string
+		exp     string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "builtin and custom",
+			exp:  "isSorted(object.items) && join(object.abc, ', ') != ''",
+			want: []string{"isSorted", "join"},
+		},
+		{
+			name: "duplicates and nesting",
+			exp:  "len(filter(object.items, # > 1)) == len(map(object.items, abs(#)))",
+			want: []string{"abs", "filter", "len", "map"},
+		},
+		{
+			name: "pipes",
+			exp:  "object.image | split(':') | len()",
+			want: []string{"len", "split"},
+		},
+		{
+			name: "methods are not functions",
+			exp:  "url(object.href).getScheme() == 'https'",
+			want: []string{"url"},
+		},
+		{
+			name: "string closure bodies",
+			exp:  "allMatch(object.items, 'len(#) > 0')",
+			want: []string{"allMatch", "len"},
+		},
+		{
+			name: "sets namespace",
+			exp:  "sets.contains(a, b) || sets.equivalent(a, b)",
+			want: []string{"sets.contains", "sets.equivalent"},
+		},
+		{
+			name: "regex methods",
+			exp:  "object.image.find('v[0-9]+') != '' && len(object.image.findAll('[0-9]+')) > 1",
+			want: []string{"find", "findAll", "len"},
+		},
+		{
+			name: "no calls",
+			exp:  "object.replicas <= 5 && 'a' in object.abc",
+			want: []string{},
+		},
+		{
+			name:    "syntax error",
+			exp:     "isSorted(",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UsedFunctions(tt.exp)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UsedFunctions() got error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UsedFunctions() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
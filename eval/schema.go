@@ -0,0 +1,204 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// schemaDialect is the JSON Schema version the schemas returned by EvalWithSchema conform to.
+const schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+var (
+	durationType  = reflect.TypeOf(time.Duration(0))
+	timeType      = reflect.TypeOf(time.Time{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// EvalWithSchema evaluates the expr expression against the given input and returns the result as JSON, along with a
+// JSON Schema the result validates against. The schema is built from the type the checker inferred for the result,
+// and from the result itself where that type is only known at runtime, such as the keys of a map or the elements of
+// a list of any. Lists whose elements have different shapes are described with anyOf.
+func EvalWithSchema(exp string, input map[string]any, opts ...Option) (result string, schema string, err error) {
+	o := newOptions(opts)
+	program, _, err := compile(exp, compileOptions(input), o)
+	if err != nil {
+		return "", "", err
+	}
+	res, err := execute(context.Background(), program, input, o)
+	if err != nil {
+		return "", "", err
+	}
+	output := res.Result
+
+	s, err := schemaFor(program.Node().Type(), output)
+	if err != nil {
+		return "", "", formatError(PhaseMarshal, err)
+	}
+	s["$schema"] = schemaDialect
+
+	out, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return "", "", formatError(PhaseMarshal, err)
+	}
+	sch, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return "", "", formatError(PhaseMarshal, err)
+	}
+	return string(out), string(sch), nil
+}
+
+// schemaFor returns a JSON Schema for v, a value of type t. When t is an interface type, or nil, the type of v is
+// used instead.
+func schemaFor(t reflect.Type, v any) (map[string]any, error) {
+	if t == nil || t.Kind() == reflect.Interface {
+		if v == nil {
+			return map[string]any{"type": "null"}, nil
+		}
+		t = reflect.TypeOf(v)
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	case t == durationType:
+		// Durations are encoded as a number of nanoseconds.
+		return map[string]any{"type": "integer"}, nil
+	case t == reflect.TypeOf(json.Number("")):
+		if n, ok := v.(json.Number); ok && strings.ContainsAny(string(n), ".eE") {
+			return map[string]any{"type": "number"}, nil
+		}
+		return map[string]any{"type": "integer"}, nil
+	case t.Implements(marshalerType) || t.Kind() == reflect.Struct:
+		return encodedSchema(v)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Slice, reflect.Array:
+		return listSchema(t, v)
+	case reflect.Map:
+		return mapSchema(t, v)
+	case reflect.Pointer:
+		rv := reflect.ValueOf(v)
+		if v == nil || rv.IsNil() {
+			return map[string]any{"type": "null"}, nil
+		}
+		return schemaFor(t.Elem(), rv.Elem().Interface())
+	}
+	return nil, fmt.Errorf("cannot describe values of type %s", t)
+}
+
+// listSchema returns a JSON Schema for the list v, a value of type t. The schema of the items is the schema every
+// element shares, or anyOf the distinct element schemas.
+func listSchema(t reflect.Type, v any) (map[string]any, error) {
+	out := map[string]any{"type": "array"}
+	rv := reflect.ValueOf(v)
+	if v == nil || rv.Len() == 0 {
+		if t.Elem().Kind() != reflect.Interface {
+			items, err := schemaFor(t.Elem(), nil)
+			if err != nil {
+				return nil, err
+			}
+			out["items"] = items
+		}
+		return out, nil
+	}
+
+	var items []map[string]any
+	for i := 0; i < rv.Len(); i++ {
+		s, err := schemaFor(t.Elem(), rv.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		if !containsSchema(items, s) {
+			items = append(items, s)
+		}
+	}
+	if len(items) == 1 {
+		out["items"] = items[0]
+	} else {
+		out["items"] = map[string]any{"anyOf": items}
+	}
+	return out, nil
+}
+
+// mapSchema returns a JSON Schema for the map v, a value of type t, describing each of its keys as a required
+// property.
+func mapSchema(t reflect.Type, v any) (map[string]any, error) {
+	out := map[string]any{"type": "object"}
+	rv := reflect.ValueOf(v)
+	if v == nil || rv.Len() == 0 {
+		return out, nil
+	}
+
+	properties := make(map[string]any, rv.Len())
+	required := make([]string, 0, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		key := fmt.Sprint(iter.Key().Interface())
+		s, err := schemaFor(t.Elem(), iter.Value().Interface())
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", key, err)
+		}
+		properties[key] = s
+		required = append(required, key)
+	}
+	sort.Strings(required)
+	out["properties"] = properties
+	out["required"] = required
+	return out, nil
+}
+
+// encodedSchema returns a JSON Schema for v based on its JSON encoding. It is used for values, such as structs, whose
+// encoding does not follow from their Go type.
+func encodedSchema(v any) (map[string]any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var decoded any
+	if err := dec.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	return schemaFor(nil, decoded)
+}
+
+// containsSchema reports whether schemas contains s.
+func containsSchema(schemas []map[string]any, s map[string]any) bool {
+	for _, e := range schemas {
+		if reflect.DeepEqual(e, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEvalWithSchema(t *testing.T) {
+	tests := []struct {
+		name       string
+		exp        string
+		wantResult any
+		wantSchema map[string]any
+	}{
+		{
+			name:       "bool",
+			exp:        "object.replicas <= 5",
+			wantResult: true,
+			wantSchema: map[string]any{
+				"$schema": schemaDialect,
+				"type":    "boolean",
+			},
+		},
+		{
+			name: "list of maps",
+			exp:  "map(object.items, {{'item': #, 'even': # % 2 == 0}})",
+			wantResult: []any{
+				map[string]any{"item": 1.0, "even": false},
+				map[string]any{"item": 2.0, "even": true},
+				map[string]any{"item": 3.0, "even": false},
+			},
+			wantSchema: map[string]any{
+				"$schema": schemaDialect,
+				"type":    "array",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"item": map[string]any{"type": "integer"},
+						"even": map[string]any{"type": "boolean"},
+					},
+					"required": []any{"even", "item"},
+				},
+			},
+		},
+		{
+			name:       "list of mixed elements",
+			exp:        "[1, 'a', 2.5, nil]",
+			wantResult: []any{1.0, "a", 2.5, nil},
+			wantSchema: map[string]any{
+				"$schema": schemaDialect,
+				"type":    "array",
+				"items": map[string]any{
+					"anyOf": []any{
+						map[string]any{"type": "integer"},
+						map[string]any{"type": "string"},
+						map[string]any{"type": "number"},
+						map[string]any{"type": "null"},
+					},
+				},
+			},
+		},
+		{
+			name:       "empty typed list",
+			exp:        "filter(['a'], # == 'b')",
+			wantResult: []any{},
+			wantSchema: map[string]any{
+				"$schema": schemaDialect,
+				"type":    "array",
+			},
+		},
+		{
+			name:       "time",
+			exp:        "date('2024-02-26')",
+			wantResult: "2024-02-26T00:00:00Z",
+			wantSchema: map[string]any{
+				"$schema": schemaDialect,
+				"type":    "string",
+				"format":  "date-time",
+			},
+		},
+		{
+			name:       "value with a custom JSON encoding",
+			exp:        "url('https://example.com:8080/path')",
+			wantResult: "https://example.com:8080/path",
+			wantSchema: map[string]any{
+				"$schema": schemaDialect,
+				"type":    "string",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, schema, err := EvalWithSchema(tt.exp, input)
+			if err != nil {
+				t.Fatalf("EvalWithSchema() got error = %v, want %v", err, nil)
+			}
+
+			var gotResult any
+			if err := json.Unmarshal([]byte(result), &gotResult); err != nil {
+				t.Fatalf("failed to decode result: %v", err)
+			}
+			if diff := cmp.Diff(tt.wantResult, gotResult); diff != "" {
+				t.Errorf("EvalWithSchema() result mismatch (-want +got):\n%s", diff)
+			}
+
+			var gotSchema map[string]any
+			if err := json.Unmarshal([]byte(schema), &gotSchema); err != nil {
+				t.Fatalf("failed to decode schema: %v", err)
+			}
+			if diff := cmp.Diff(tt.wantSchema, gotSchema); diff != "" {
+				t.Errorf("EvalWithSchema() schema mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEvalWithSchemaError(t *testing.T) {
+	if _, _, err := EvalWithSchema("object.replicas +", input); err == nil {
+		t.Errorf("EvalWithSchema() got error = %v, want an error", err)
+	}
+}
+
+func TestEvalWithSchemaOptions(t *testing.T) {
+	if _, _, err := EvalWithSchema("{1: 'a'}", input, strict()); err == nil {
+		t.Errorf("EvalWithSchema() in strict mode got error = %v, want a compile error", err)
+	}
+	result, _, err := EvalWithSchema("missing ?? true", input, lenient())
+	if err != nil {
+		t.Fatalf("EvalWithSchema() in lenient mode got error = %v, want %v", err, nil)
+	}
+	if result != "true" {
+		t.Errorf("EvalWithSchema() in lenient mode = %q, want %q", result, "true")
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/file"
+	"github.com/expr-lang/expr/vm"
+)
+
+// EvalStrict evaluates the expr expression against the given input like Eval, but rejects map literals with keys
+// that are not strings. Expr converts a key written as a number, as in {1: 'a'}, to the string "1" without notice,
+// and a parenthesized key of another type fails only once the map is built. In strict mode both are compile errors
+// that point at the offending key.
+func EvalStrict(exp string, input map[string]any, opts ...Option) (string, error) {
+	return eval(context.Background(), exp, input, input, append(opts, strict())...)
+}
+
+// strict enables the checks of EvalStrict.
+func strict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// checkStrict returns an error locating the first map key in the compiled program that is not a string.
+func checkStrict(exp string, program *vm.Program) error {
+	source := file.NewSource(exp)
+	if keys := numericMapKeys(exp); len(keys) > 0 {
+		err := &file.Error{
+			Location: keys[0].Location,
+			Message:  "map key " + keys[0].Value + " must be a string, quote it to use it as a key",
+		}
+		return err.Bind(source)
+	}
+
+	v := &mapKeyVisitor{}
+	node := program.Node()
+	ast.Walk(&node, v)
+	if v.err != nil {
+		return v.err.Bind(source)
+	}
+	return nil
+}
+
+// mapKeyVisitor records an error for the first map key whose type is known to be something other than a string.
+type mapKeyVisitor struct {
+	err *file.Error
+}
+
+func (v *mapKeyVisitor) Visit(node *ast.Node) {
+	pair, ok := (*node).(*ast.PairNode)
+	if !ok || v.err != nil {
+		return
+	}
+	t := pair.Key.Type()
+	if t == nil || t.Kind() == reflect.String || t.Kind() == reflect.Interface {
+		return
+	}
+	v.err = &file.Error{
+		Location: pair.Key.Location(),
+		Message:  "map key of type " + typeName(t) + " must be a string",
+	}
+}
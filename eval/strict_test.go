@@ -0,0 +1,114 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/expr-lang/expr/file"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEvalStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		exp     string
+		want    any
+		wantErr *file.Error
+	}{
+		{
+			name: "string keys",
+			exp:  `{'hello': 'world', name: '!'}`,
+			want: map[string]any{"hello": "world", "name": "!"},
+		},
+		{
+			name: "computed string key",
+			exp:  `{('a' + 'b'): 1}`,
+			want: map[string]any{"ab": float64(1)},
+		},
+		{
+			name: "integer key",
+			exp:  `{'hello':'world', 1:'!'}`,
+			wantErr: &file.Error{
+				Location: file.Location{Line: 1, Column: 18},
+				Message:  "map key 1 must be a string, quote it to use it as a key",
+			},
+		},
+		{
+			name: "float key on a later line",
+			exp:  "{\n  'a': 1,\n  2.5: 'b'\n}",
+			wantErr: &file.Error{
+				Location: file.Location{Line: 3, Column: 2},
+				Message:  "map key 2.5 must be a string, quote it to use it as a key",
+			},
+		},
+		{
+			name: "computed int key",
+			exp:  `{'a': {(len('ab')): 1}}`,
+			wantErr: &file.Error{
+				Location: file.Location{Line: 1, Column: 8},
+				Message:  "map key of type int must be a string",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvalStrict(tt.exp, input)
+			if tt.wantErr != nil {
+				var fileErr *file.Error
+				if !errors.As(err, &fileErr) {
+					t.Fatalf("EvalStrict() got error = %v, want a *file.Error", err)
+				}
+				if diff := cmp.Diff(tt.wantErr.Location, fileErr.Location); diff != "" {
+					t.Errorf("EvalStrict() error location mismatch (-want +got):\n%s", diff)
+				}
+				if fileErr.Message != tt.wantErr.Message {
+					t.Errorf("EvalStrict() error message = %q, want %q", fileErr.Message, tt.wantErr.Message)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EvalStrict() got error = %v, want %v", err, nil)
+			}
+
+			var res RunResponse
+			if err := json.Unmarshal([]byte(got), &res); err != nil {
+				t.Fatalf("json.Unmarshal got error = %v, want %v", err, nil)
+			}
+			if diff := cmp.Diff(tt.want, res.Result); diff != "" {
+				t.Errorf("EvalStrict() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEvalLenientMapKeys(t *testing.T) {
+	got, err := Eval(`{'hello':'world', 1:'!'}`, input)
+	if err != nil {
+		t.Fatalf("Eval() got error = %v, want %v", err, nil)
+	}
+
+	var res RunResponse
+	if err := json.Unmarshal([]byte(got), &res); err != nil {
+		t.Fatalf("json.Unmarshal got error = %v, want %v", err, nil)
+	}
+	want := map[string]any{"hello": "world", "1": "!"}
+	if diff := cmp.Diff(want, res.Result); diff != "" {
+		t.Errorf("Eval() mismatch (-want +got):\n%s", diff)
+	}
+}
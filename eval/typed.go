@@ -0,0 +1,157 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// typeNames are the type names EvalTyped accepts. Any of them may be prefixed with "[]" to declare a list.
+var typeNames = map[string]reflect.Type{
+	"any":      reflect.TypeOf((*any)(nil)).Elem(),
+	"bool":     reflect.TypeOf(false),
+	"int":      reflect.TypeOf(0),
+	"float":    reflect.TypeOf(0.0),
+	"string":   reflect.TypeOf(""),
+	"list":     reflect.TypeOf([]any{}),
+	"map":      reflect.TypeOf(map[string]any{}),
+	"duration": reflect.TypeOf(time.Duration(0)),
+	"time":     reflect.TypeOf(time.Time{}),
+}
+
+// EvalTyped evaluates the expr expression against the given input like Eval, but type checks it against the declared
+// types of the variables named in types rather than the types of their values in input. This lets the checker catch
+// mistakes that Eval would only find at runtime, such as comparing a variable that is nil in the input against the
+// wrong type. Type names are those in typeNames, optionally prefixed with "[]" for a list, such as "[]string". Input
+// values must be nil or convertible to their declared type, see convert. Errors are described by ErrorFormatter.
+func EvalTyped(exp string, input map[string]any, types map[string]string, opts ...Option) (string, error) {
+	env := make(map[string]any, len(input)+len(types))
+	values := make(map[string]any, len(input))
+	for k, v := range input {
+		env[k] = v
+		values[k] = v
+	}
+	for name, typeName := range types {
+		t, err := parseType(typeName)
+		if err != nil {
+			return "", formatError(PhaseCompile, fmt.Errorf("variable %q: %w", name, err))
+		}
+		env[name] = reflect.Zero(t).Interface()
+
+		if v := input[name]; v != nil {
+			cv, ok := convert(reflect.ValueOf(v), t)
+			if !ok {
+				err := fmt.Errorf("variable %q: declared as %s but the input is %T", name, typeName, v)
+				return "", formatError(PhaseCompile, err)
+			}
+			values[name] = cv.Interface()
+		}
+	}
+	return eval(context.Background(), exp, env, values, opts...)
+}
+
+// convert returns v as type t. Values are converted when they are assignable to t, when both are numbers and the
+// number fits t exactly, or when both are lists and every element converts. Decoded input holds lists as []any, so
+// this is what lets a list from the input be declared as, say, []string.
+func convert(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Zero(t), true
+		}
+		v = v.Elem()
+	}
+	switch {
+	case v.Type().AssignableTo(t):
+		return v, true
+	case isNumber(v.Type()) && isNumber(t):
+		if !fitsInteger(v, t) {
+			return reflect.Value{}, false
+		}
+		return v.Convert(t), true
+	case v.Kind() == reflect.Slice && t.Kind() == reflect.Slice:
+		out := reflect.MakeSlice(t, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			e, ok := convert(v.Index(i), t.Elem())
+			if !ok {
+				return reflect.Value{}, false
+			}
+			out.Index(i).Set(e)
+		}
+		return out, true
+	}
+	return reflect.Value{}, false
+}
+
+// fitsInteger reports whether the number v converts to t without losing its value. Any number fits a float type; an
+// integer type only fits whole numbers within its range, so 2.7 is not silently truncated to 2.
+func fitsInteger(v reflect.Value, t reflect.Type) bool {
+	target := reflect.New(t).Elem()
+	switch {
+	case target.CanFloat():
+		return true
+	case v.CanFloat():
+		f := v.Float()
+		if f != math.Trunc(f) || math.IsInf(f, 0) {
+			return false
+		}
+		if target.CanInt() {
+			return f >= math.MinInt64 && f < math.MaxInt64 && !target.OverflowInt(int64(f))
+		}
+		return f >= 0 && f < math.MaxUint64 && !target.OverflowUint(uint64(f))
+	case v.CanInt():
+		i := v.Int()
+		if target.CanInt() {
+			return !target.OverflowInt(i)
+		}
+		return i >= 0 && !target.OverflowUint(uint64(i))
+	default:
+		u := v.Uint()
+		if target.CanInt() {
+			return u <= math.MaxInt64 && !target.OverflowInt(int64(u))
+		}
+		return !target.OverflowUint(u)
+	}
+}
+
+func isNumber(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// parseType returns the type named by name.
+func parseType(name string) (reflect.Type, error) {
+	if elem, ok := strings.CutPrefix(name, "[]"); ok {
+		t, err := parseType(elem)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.SliceOf(t), nil
+	}
+	t, ok := typeNames[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %q", name)
+	}
+	return t, nil
+}
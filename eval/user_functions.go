@@ -0,0 +1,229 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/conf"
+	"github.com/expr-lang/expr/vm"
+)
+
+// declarationPattern matches a user function declaration, a name optionally followed by a parenthesized list of
+// parameter names, as in double(x).
+var declarationPattern = regexp.MustCompile(`^\s*([A-Za-z_][A-Za-z0-9_]*)\s*(?:\(([^()]*)\))?\s*$`)
+
+// identifierPattern matches a valid parameter name.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+var anyType = reflect.TypeOf((*any)(nil)).Elem()
+
+// userFunction is a function defined by an Expr expression.
+type userFunction struct {
+	name   string
+	params []string
+	body   string
+	// calls holds the names of the other user functions the body calls.
+	calls []string
+}
+
+// EvalWithFunctions evaluates the expr expression against the given input like Eval, with additional functions
+// defined in Expr. Each key of defs declares a function and its parameters, as in "double(x)", and its value is the
+// body, as in "x * 2". The body sees the input alongside the parameters, which hide input variables of the same name,
+// and may call the other functions in defs, but not itself, directly or through another function. A function's
+// arity is checked when the expression is compiled.
+func EvalWithFunctions(exp string, input map[string]any, defs map[string]string, opts ...Option) (string, error) {
+	fns, err := userFunctions(input, defs)
+	if err != nil {
+		return "", formatError(PhaseCompile, err)
+	}
+	compileOpts := append(compileOptions(input), fns...)
+	res, err := run(context.Background(), exp, compileOpts, input, newOptions(opts))
+	if err != nil {
+		return "", err
+	}
+	out, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return "", formatError(PhaseMarshal, err)
+	}
+	return string(out), nil
+}
+
+// userFunctions compiles defs, returning options that register each as an Expr function. Functions are compiled
+// after the functions they call, so each body is type checked against the functions it depends on.
+func userFunctions(input map[string]any, defs map[string]string) ([]expr.Option, error) {
+	// The functions every expression can call, which user functions must not shadow.
+	reserved := conf.CreateNew()
+	for _, opt := range compileOptions(input) {
+		opt(reserved)
+	}
+
+	fns := make(map[string]*userFunction, len(defs))
+	for decl, body := range defs {
+		fn, err := parseDeclaration(decl)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := reserved.Functions[fn.name]; ok {
+			return nil, fmt.Errorf("function %s is already defined", fn.name)
+		}
+		if _, ok := reserved.Builtins[fn.name]; ok && !reserved.Disabled[fn.name] {
+			return nil, fmt.Errorf("function %s is already defined as a builtin", fn.name)
+		}
+		if _, ok := fns[fn.name]; ok {
+			return nil, fmt.Errorf("function %s is declared more than once", fn.name)
+		}
+		fn.body = body
+		fns[fn.name] = fn
+	}
+	for _, fn := range fns {
+		used, err := UsedFunctions(fn.body)
+		if err != nil {
+			return nil, fmt.Errorf("function %s: %w", fn.name, err)
+		}
+		for _, name := range used {
+			if _, ok := fns[name]; ok {
+				fn.calls = append(fn.calls, name)
+			}
+		}
+	}
+
+	order, err := callOrder(fns)
+	if err != nil {
+		return nil, err
+	}
+	var opts []expr.Option
+	for _, fn := range order {
+		opt, err := fn.compile(input, opts)
+		if err != nil {
+			return nil, fmt.Errorf("function %s: %w", fn.name, err)
+		}
+		opts = append(opts, opt)
+	}
+	return opts, nil
+}
+
+// parseDeclaration parses a user function declaration such as double(x).
+func parseDeclaration(decl string) (*userFunction, error) {
+	m := declarationPattern.FindStringSubmatch(decl)
+	if m == nil {
+		return nil, fmt.Errorf("invalid function declaration %q, want a name and parameters such as double(x)", decl)
+	}
+	fn := &userFunction{name: m[1]}
+	if strings.TrimSpace(m[2]) == "" {
+		return fn, nil
+	}
+	seen := make(map[string]bool)
+	for _, p := range strings.Split(m[2], ",") {
+		p = strings.TrimSpace(p)
+		if !identifierPattern.MatchString(p) {
+			return nil, fmt.Errorf("function %s: invalid parameter name %q", fn.name, p)
+		}
+		if seen[p] {
+			return nil, fmt.Errorf("function %s: parameter %s is declared more than once", fn.name, p)
+		}
+		seen[p] = true
+		fn.params = append(fn.params, p)
+	}
+	return fn, nil
+}
+
+// callOrder returns fns ordered so that every function comes after the functions it calls. It returns an error
+// naming the cycle if a function calls itself, directly or through other functions.
+func callOrder(fns map[string]*userFunction) ([]*userFunction, error) {
+	names := make([]string, 0, len(fns))
+	for name := range fns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(fns))
+	var order []*userFunction
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		path = append(path, name)
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("function %s is recursive: %s", name, strings.Join(path, " -> "))
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		fn := fns[name]
+		sort.Strings(fn.calls)
+		for _, callee := range fn.calls {
+			if err := visit(callee, path); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		order = append(order, fn)
+		return nil
+	}
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// compile compiles the body of fn, which may call the functions registered by deps, and returns an option that
+// registers fn as an Expr function.
+func (fn *userFunction) compile(input map[string]any, deps []expr.Option) (expr.Option, error) {
+	// Parameters are declared as any, since their values are only known when the function is called.
+	params := func(c *conf.Config) {
+		for _, p := range fn.params {
+			c.Types[p] = conf.Tag{Type: anyType}
+		}
+	}
+	opts := append(compileOptions(input), params)
+	program, err := expr.Compile(fn.body, append(opts, deps...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	in := make([]reflect.Type, len(fn.params))
+	for i := range in {
+		in[i] = anyType
+	}
+	signature := reflect.New(reflect.FuncOf(in, []reflect.Type{anyType}, false)).Interface()
+
+	return expr.Function(fn.name, func(params ...any) (any, error) {
+		if len(params) != len(fn.params) {
+			return nil, fmt.Errorf("%s expects %d parameters, got %d", fn.name, len(fn.params), len(params))
+		}
+		env := make(map[string]any, len(input)+len(params))
+		for k, v := range input {
+			env[k] = v
+		}
+		for i, p := range fn.params {
+			env[p] = params[i]
+		}
+		var machine vm.VM
+		return machine.Run(program, env)
+	}, signature), nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEvalWithFunctions(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		defs map[string]string
+		want any
+	}{
+		{
+			name: "double",
+			exp:  "double(object.replicas)",
+			defs: map[string]string{"double(x)": "x * 2"},
+			want: 4.0,
+		},
+		{
+			name: "called from a closure",
+			exp:  "map(object.items, double(#))",
+			defs: map[string]string{"double(x)": "x * 2"},
+			want: []any{2.0, 4.0, 6.0},
+		},
+		{
+			name: "several parameters",
+			exp:  "between(object.replicas, 1, 5)",
+			defs: map[string]string{"between(x, lo, hi)": "x >= lo && x <= hi"},
+			want: true,
+		},
+		{
+			name: "no parameters",
+			exp:  "limit() > object.replicas",
+			defs: map[string]string{"limit": "10"},
+			want: true,
+		},
+		{
+			name: "reads the input",
+			exp:  "scaled(3)",
+			defs: map[string]string{"scaled(x)": "x * object.replicas"},
+			want: 6.0,
+		},
+		{
+			name: "parameters hide input variables",
+			exp:  "id(1)",
+			defs: map[string]string{"id(object)": "object"},
+			want: 1.0,
+		},
+		{
+			name: "calls another function",
+			exp:  "quadruple(object.replicas)",
+			defs: map[string]string{
+				"double(x)":    "x * 2",
+				"quadruple(x)": "double(double(x))",
+			},
+			want: 8.0,
+		},
+		{
+			name: "uses the custom functions",
+			exp:  "isVersion(object.image)",
+			defs: map[string]string{"isVersion(s)": "s.find('v[0-9]+') != ''"},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvalWithFunctions(tt.exp, input, tt.defs)
+			if err != nil {
+				t.Fatalf("EvalWithFunctions() got error = %v, want %v", err, nil)
+			}
+			var res RunResponse
+			if err := json.Unmarshal([]byte(got), &res); err != nil {
+				t.Fatalf("json.Unmarshal got error = %v, want %v", err, nil)
+			}
+			if diff := cmp.Diff(tt.want, res.Result); diff != "" {
+				t.Errorf("EvalWithFunctions() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEvalWithFunctionsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		exp     string
+		defs    map[string]string
+		wantErr string
+	}{
+		{
+			name:    "recursive",
+			exp:     "fact(3)",
+			defs:    map[string]string{"fact(n)": "n <= 1 ? 1 : n * fact(n - 1)"},
+			wantErr: "function fact is recursive: fact -> fact",
+		},
+		{
+			name: "mutually recursive",
+			exp:  "isEven(3)",
+			defs: map[string]string{
+				"isEven(n)": "n == 0 || isOdd(n - 1)",
+				"isOdd(n)":  "n != 0 && isEven(n - 1)",
+			},
+			wantErr: "function isEven is recursive: isEven -> isOdd -> isEven",
+		},
+		{
+			name:    "wrong number of arguments",
+			exp:     "double(1, 2)",
+			defs:    map[string]string{"double(x)": "x * 2"},
+			wantErr: "too many arguments to call double",
+		},
+		{
+			name:    "invalid body",
+			exp:     "double(1)",
+			defs:    map[string]string{"double(x)": "x *"},
+			wantErr: "function double: failed to parse the Expr expression: unexpected token EOF",
+		},
+		{
+			name:    "invalid declaration",
+			exp:     "double(1)",
+			defs:    map[string]string{"double(": "1"},
+			wantErr: `invalid function declaration "double("`,
+		},
+		{
+			name:    "invalid parameter",
+			exp:     "double(1)",
+			defs:    map[string]string{"double(1x)": "1"},
+			wantErr: `function double: invalid parameter name "1x"`,
+		},
+		{
+			name:    "duplicate parameter",
+			exp:     "add(1, 1)",
+			defs:    map[string]string{"add(x, x)": "x + x"},
+			wantErr: "function add: parameter x is declared more than once",
+		},
+		{
+			name:    "declared twice",
+			exp:     "double(1)",
+			defs:    map[string]string{"double(x)": "x * 2", "double (y)": "y * 2"},
+			wantErr: "function double is declared more than once",
+		},
+		{
+			name:    "shadows a builtin",
+			exp:     "len(1)",
+			defs:    map[string]string{"len(x)": "x"},
+			wantErr: "function len is already defined as a builtin",
+		},
+		{
+			name:    "shadows a custom function",
+			exp:     "isSorted(1)",
+			defs:    map[string]string{"isSorted(x)": "true"},
+			wantErr: "function isSorted is already defined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := EvalWithFunctions(tt.exp, input, tt.defs)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("EvalWithFunctions() got error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
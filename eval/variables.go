@@ -0,0 +1,58 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"slices"
+
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/parser/utils"
+	"github.com/expr-lang/expr/vm"
+)
+
+// letVariables maps the name of each let-bound variable in program to its value in values, the variable slots of
+// the VM that ran it. The compiler numbers the slots in the order it compiles the let declarations of the program's
+// tree, so the declarations are collected from that tree in the same order. Declarations added by patches use names
+// that are not valid identifiers and are left out. If a name is bound more than once, the last binding wins.
+func letVariables(program *vm.Program, values []any) map[string]any {
+	c := &letCollector{}
+	node := program.Node()
+	ast.Walk(&node, c)
+
+	out := make(map[string]any)
+	for slot, decl := range c.lets {
+		if slot < len(values) && utils.IsValidIdentifier(decl.Name) {
+			out[decl.Name] = values[slot]
+		}
+	}
+	return out
+}
+
+// letCollector collects the let declarations in an AST in the order the compiler gives them variable slots. A
+// declaration gets its slot after the declarations in its value and before those in its body, but ast.Walk visits it
+// after both, so it is moved in front of the declarations collected from its body.
+type letCollector struct {
+	lets []*ast.VariableDeclaratorNode
+}
+
+func (c *letCollector) Visit(node *ast.Node) {
+	n, ok := (*node).(*ast.VariableDeclaratorNode)
+	if !ok {
+		return
+	}
+	body := &letCollector{}
+	ast.Walk(&n.Expr, body)
+	c.lets = slices.Insert(c.lets, len(c.lets)-len(body.lets), n)
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/file"
+	"github.com/expr-lang/expr/parser"
+	"github.com/expr-lang/expr/parser/lexer"
+)
+
+// warnings returns non-fatal observations about patterns in the expression that compile but are likely mistakes,
+// such as a map key written as a number, which Expr silently converts to a string. They are informational only.
+func warnings(exp string) []string {
+	var out []string
+	for _, key := range numericMapKeys(exp) {
+		out = append(out, fmt.Sprintf("map key %s is converted to the string %q%s", key.Value, key.Value, at(key.Location)))
+	}
+
+	tree, err := parser.Parse(exp)
+	if err != nil {
+		return out
+	}
+	w := &warningVisitor{}
+	ast.Walk(&tree.Node, w)
+	return append(out, w.warnings...)
+}
+
+// warningVisitor collects warnings about suspicious nodes while walking the AST.
+type warningVisitor struct {
+	warnings []string
+}
+
+func (w *warningVisitor) Visit(node *ast.Node) {
+	n, ok := (*node).(*ast.BinaryNode)
+	if !ok || n.Operator != "in" {
+		return
+	}
+	list, ok := n.Right.(*ast.ArrayNode)
+	if !ok {
+		return
+	}
+	if kinds := literalKinds(list.Nodes); len(kinds) > 1 {
+		last := len(kinds) - 1
+		mixed := strings.Join(kinds[:last], ", ") + " and " + kinds[last]
+		w.warnings = append(w.warnings, fmt.Sprintf("the list on the right of in mixes %s elements%s", mixed, at(list.Location())))
+	}
+}
+
+// literalKinds returns the sorted, distinct kinds of the literal nodes in nodes. Integers and floats are both
+// "number", since Expr compares them by value. Nodes that are not literals are ignored.
+func literalKinds(nodes []ast.Node) []string {
+	seen := make(map[string]bool)
+	for _, n := range nodes {
+		switch n.(type) {
+		case *ast.IntegerNode, *ast.FloatNode:
+			seen["number"] = true
+		case *ast.StringNode:
+			seen["string"] = true
+		case *ast.BoolNode:
+			seen["bool"] = true
+		case *ast.NilNode:
+			seen["nil"] = true
+		}
+	}
+	kinds := make([]string, 0, len(seen))
+	for k := range seen {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// numericMapKeys returns the tokens of map literal keys written as numbers. The parser turns such keys into strings
+// before building the AST, so they can only be found in the token stream.
+func numericMapKeys(exp string) []lexer.Token {
+	tokens, err := lexer.Lex(file.NewSource(exp))
+	if err != nil {
+		return nil
+	}
+
+	var keys []lexer.Token
+	// brackets holds the open brackets enclosing the current token, and expectKey whether the current token starts a
+	// key of the innermost map.
+	var brackets []string
+	expectKey := false
+	for i, t := range tokens {
+		atKey := expectKey
+		expectKey = false
+		switch {
+		case t.Is(lexer.Bracket, "{"):
+			brackets = append(brackets, t.Value)
+			expectKey = true
+		case t.Is(lexer.Bracket, "(", "["):
+			brackets = append(brackets, t.Value)
+		case t.Is(lexer.Bracket, "}", ")", "]"):
+			if len(brackets) > 0 {
+				brackets = brackets[:len(brackets)-1]
+			}
+		case t.Is(lexer.Operator, ","):
+			expectKey = len(brackets) > 0 && brackets[len(brackets)-1] == "{"
+		case atKey && t.Is(lexer.Number) && i+1 < len(tokens) && tokens[i+1].Is(lexer.Operator, ":"):
+			keys = append(keys, t)
+		}
+	}
+	return keys
+}
+
+// at formats a location as a suffix in the same style as Expr errors, with a 1-based column.
+func at(loc file.Location) string {
+	if loc.Line == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%d:%d)", loc.Line, loc.Column+1)
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eval
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWarnings(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want []string
+	}{
+		{
+			name: "clean",
+			exp:  `object.replicas in [1, 2, 3] && {'replicas': object.replicas}.replicas == 2`,
+		},
+		{
+			name: "integer map key",
+			exp:  `{'hello':'world', 1:'!'}`,
+			want: []string{`map key 1 is converted to the string "1" (1:19)`},
+		},
+		{
+			name: "nested float map key",
+			exp:  `{'a': [{1.5: true}]}`,
+			want: []string{`map key 1.5 is converted to the string "1.5" (1:9)`},
+		},
+		{
+			name: "number as a map value",
+			exp:  `{'a': 1, b: 2 > 1 ? 3 : 4}`,
+		},
+		{
+			name: "mixed in list",
+			exp:  `object.image in ['hello', 0]`,
+			want: []string{"the list on the right of in mixes number and string elements (1:17)"},
+		},
+		{
+			name: "mixed not in list",
+			exp:  `object.replicas not in [1, nil, true]`,
+			want: []string{"the list on the right of in mixes bool, nil and number elements (1:24)"},
+		},
+		{
+			name: "ints and floats are not mixed",
+			exp:  `object.replicas in [1, 2.5]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Eval(tt.exp, input)
+			if err != nil {
+				t.Fatalf("Eval() got error = %v, want %v", err, nil)
+			}
+
+			var res RunResponse
+			if err := json.Unmarshal([]byte(got), &res); err != nil {
+				t.Fatalf("json.Unmarshal got error = %v, want %v", err, nil)
+			}
+			if diff := cmp.Diff(tt.want, res.Warnings); diff != "" {
+				t.Errorf("Eval() warnings mismatch (-want +got):\n%s", diff)
+			}
+
+			check, err := Check(tt.exp, input)
+			if err != nil {
+				t.Fatalf("Check() got error = %v, want %v", err, nil)
+			}
+			if diff := cmp.Diff(tt.want, check.Warnings); diff != "" {
+				t.Errorf("Check() warnings mismatch (-want +got):\n%s", diff)
+			}
+
+			diag, err := Validate(tt.exp, input)
+			if err != nil {
+				t.Fatalf("Validate() got error = %v, want %v", err, nil)
+			}
+			if diff := cmp.Diff(tt.want, diag.Warnings); diff != "" {
+				t.Errorf("Validate() warnings mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
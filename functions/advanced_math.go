@@ -0,0 +1,106 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/expr-lang/expr"
+)
+
+// AdvancedMath provides the pow, sqrt, log, log10, and log2 functions as Expr functions. They all return floats.
+// Results that are not finite numbers, such as the square root of a negative number or the logarithm of zero, are
+// returned as errors since they cannot be represented in the JSON output.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.AdvancedMath())
+//
+// Expression:
+//
+//	pow(2, 10)  // 1024.0
+//	sqrt(16)    // 4.0
+//	log(1)      // 0.0
+//	log10(1000) // 3.0
+//	log2(8)     // 3.0
+func AdvancedMath() expr.Option {
+	return options(
+		expr.Function("pow", func(params ...any) (any, error) {
+			if len(params) != 2 {
+				return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+			}
+			base, err := argFloat(params, 0)
+			if err != nil {
+				return nil, err
+			}
+			exp, err := argFloat(params, 1)
+			if err != nil {
+				return nil, err
+			}
+			return finite("pow", math.Pow(base, exp))
+		},
+			new(func(float64, float64) (float64, error)),
+		),
+		mathFunc("sqrt", func(x float64) (float64, error) {
+			if x < 0 {
+				return 0, fmt.Errorf("sqrt of a negative number %v", x)
+			}
+			return math.Sqrt(x), nil
+		}),
+		mathFunc("log", logFunc(math.Log)),
+		mathFunc("log10", logFunc(math.Log10)),
+		mathFunc("log2", logFunc(math.Log2)),
+	)
+}
+
+// mathFunc registers a single argument float function under name.
+func mathFunc(name string, fn func(float64) (float64, error)) expr.Option {
+	return expr.Function(name, func(params ...any) (any, error) {
+		if len(params) != 1 {
+			return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+		}
+		x, err := argFloat(params, 0)
+		if err != nil {
+			return nil, err
+		}
+		r, err := fn(x)
+		if err != nil {
+			return nil, err
+		}
+		return finite(name, r)
+	},
+		new(func(float64) (float64, error)),
+	)
+}
+
+// logFunc wraps a logarithm so that non-positive inputs, which have no real logarithm, return an error.
+func logFunc(log func(float64) float64) func(float64) (float64, error) {
+	return func(x float64) (float64, error) {
+		if x <= 0 {
+			return 0, fmt.Errorf("logarithm of a non-positive number %v", x)
+		}
+		return log(x), nil
+	}
+}
+
+// finite returns an error if r is NaN or infinite.
+func finite(name string, r float64) (float64, error) {
+	if math.IsNaN(r) || math.IsInf(r, 0) {
+		return 0, fmt.Errorf("%s result %v is not a finite number", name, r)
+	}
+	return r, nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"math"
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvancedMath(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           float64
+		wantRuntimeErr bool
+	}{
+		{
+			name: "pow",
+			exp:  `pow(2, 10)`,
+			want: 1024,
+		},
+		{
+			name: "pow - fractional exponent",
+			exp:  `pow(27, 1.0 / 3)`,
+			want: 3,
+		},
+		{
+			name: "pow - negative exponent",
+			exp:  `pow(2, -2)`,
+			want: 0.25,
+		},
+		{
+			name:           "pow - not finite",
+			exp:            `pow(0, -1)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name: "sqrt",
+			exp:  `sqrt(2.25)`,
+			want: 1.5,
+		},
+		{
+			name: "sqrt - from environment",
+			exp:  `sqrt(n)`,
+			want: 4,
+		},
+		{
+			name:           "sqrt - negative",
+			exp:            `sqrt(-4)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name: "log",
+			exp:  `log(1)`,
+			want: 0,
+		},
+		{
+			name: "log - e",
+			exp:  `log(e)`,
+			want: 1,
+		},
+		{
+			name:           "log - zero",
+			exp:            `log(0)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "log - negative",
+			exp:            `log(-1)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name: "log10",
+			exp:  `log10(1000)`,
+			want: 3,
+		},
+		{
+			name: "log2",
+			exp:  `log2(1024)`,
+			want: 10,
+		},
+		{
+			name:           "log2 - zero",
+			exp:            `log2(0)`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{"n": 16, "e": math.E}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), AdvancedMath())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tc.want, got, 1e-9)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/expr-lang/expr"
+)
+
+// BigInt provides the bigAdd, bigSub, bigMul, bigDiv, and bigCompare functions as Expr functions. They operate on
+// integers written as decimal strings, using arbitrary precision arithmetic, so values beyond the range of an int64,
+// such as token balances in wei, are handled exactly. The arithmetic functions return decimal strings. bigDiv
+// truncates toward zero, and dividing by zero is an error. bigCompare returns -1, 0, or 1 when the first operand is
+// less than, equal to, or greater than the second.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.BigInt())
+//
+// Expression:
+//
+//	bigAdd("9223372036854775807", "1")          // "9223372036854775808"
+//	bigMul("1000000000000000000", "1000")       // "1000000000000000000000"
+//	bigDiv("7", "2")                            // "3"
+//	bigCompare("10000000000000000000000", "1") // 1
+func BigInt() expr.Option {
+	return options(
+		bigFunction("bigAdd", func(a, b *big.Int) (any, error) {
+			return new(big.Int).Add(a, b).String(), nil
+		}),
+		bigFunction("bigSub", func(a, b *big.Int) (any, error) {
+			return new(big.Int).Sub(a, b).String(), nil
+		}),
+		bigFunction("bigMul", func(a, b *big.Int) (any, error) {
+			return new(big.Int).Mul(a, b).String(), nil
+		}),
+		bigFunction("bigDiv", func(a, b *big.Int) (any, error) {
+			if b.Sign() == 0 {
+				return nil, errors.New("division by zero")
+			}
+			return new(big.Int).Quo(a, b).String(), nil
+		}),
+		expr.Function("bigCompare", bigParams(func(a, b *big.Int) (any, error) {
+			return a.Cmp(b), nil
+		}),
+			new(func(string, string) (int, error)),
+		),
+	)
+}
+
+// bigFunction returns an Expr function with the given name that applies f to two decimal string operands and returns
+// a string.
+func bigFunction(name string, f func(a, b *big.Int) (any, error)) expr.Option {
+	return expr.Function(name, bigParams(f),
+		new(func(string, string) (string, error)),
+	)
+}
+
+// bigParams returns an Expr function implementation that parses two decimal string operands and applies f to them.
+func bigParams(f func(a, b *big.Int) (any, error)) func(params ...any) (any, error) {
+	return func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		operands := make([]*big.Int, len(params))
+		for i := range params {
+			s, err := arg[string](params, i)
+			if err != nil {
+				return nil, err
+			}
+			n, err := parseBigInt(s)
+			if err != nil {
+				return nil, err
+			}
+			operands[i] = n
+		}
+		return f(operands[0], operands[1])
+	}
+}
+
+// parseBigInt parses s as a base 10 integer with an optional sign.
+func parseBigInt(s string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("%q is not an integer", s)
+	}
+	return n, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBigInt(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "add past int64",
+			exp:  `bigAdd("9223372036854775807", "1")`,
+			want: "9223372036854775808",
+		},
+		{
+			name: "add negative",
+			exp:  `bigAdd("-5", "3")`,
+			want: "-2",
+		},
+		{
+			name: "subtract",
+			exp:  `bigSub("100000000000000000000", "1")`,
+			want: "99999999999999999999",
+		},
+		{
+			name: "subtract below zero",
+			exp:  `bigSub("1", "100000000000000000000")`,
+			want: "-99999999999999999999",
+		},
+		{
+			name: "multiply",
+			exp:  `bigMul("1000000000000000000", "1000")`,
+			want: "1000000000000000000000",
+		},
+		{
+			name: "exact division",
+			exp:  `bigDiv("1000000000000000000000000000000", "1000000000000")`,
+			want: "1000000000000000000",
+		},
+		{
+			name: "division truncates",
+			exp:  `bigDiv("7", "2")`,
+			want: "3",
+		},
+		{
+			name: "negative division truncates toward zero",
+			exp:  `bigDiv("-7", "2")`,
+			want: "-3",
+		},
+		{
+			name: "compare greater",
+			exp:  `bigCompare("10000000000000000000000", "9223372036854775807")`,
+			want: 1,
+		},
+		{
+			name: "compare less",
+			exp:  `bigCompare("-10000000000000000000000", "0")`,
+			want: -1,
+		},
+		{
+			name: "compare equal",
+			exp:  `bigCompare("+42", "42")`,
+			want: 0,
+		},
+		{
+			name: "composed",
+			exp:  `bigCompare(bigMul("3000000000", "3000000000"), "9000000000000000000") == 0`,
+			want: true,
+		},
+		{
+			name:           "division by zero",
+			exp:            `bigDiv("1", "0")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "non-numeric operand",
+			exp:            `bigAdd("1", "one")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "decimal operand",
+			exp:            `bigMul("1.5", "2")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "empty operand",
+			exp:            `bigCompare("", "2")`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(nil), BigInt())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, nil)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
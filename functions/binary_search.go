@@ -0,0 +1,81 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/expr-lang/expr"
+)
+
+// BinarySearch provides the binarySearch function as an Expr function. It returns the index of an element in a
+// sorted list, or -1 when the element is not present. When the list holds equal elements, the index of the first is
+// returned. Lists of numbers and lists of strings are supported, and mixing the two is an error. Because only
+// O(log n) elements are compared, it is much faster than indexOf for large sorted lists.
+//
+// The list is not checked for order, so it is the caller's responsibility to pass a sorted list; isSorted can be used
+// to check it. The result for an unsorted list is unspecified.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.BinarySearch())
+//
+// Expression:
+//
+//	binarySearch([1, 3, 5, 7], 5) // 2
+//	binarySearch([1, 3, 5, 7], 4) // -1
+func BinarySearch() expr.Option {
+	return expr.Function("binarySearch", func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		list, err := toList(params[0])
+		if err != nil {
+			return nil, err
+		}
+		i, found, err := binarySearch(list, params[1])
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return -1, nil
+		}
+		return i, nil
+	},
+		new(func([]any, any) (int, error)),
+		new(func([]int, any) (int, error)),
+		new(func([]float64, any) (int, error)),
+		new(func([]string, any) (int, error)),
+	)
+}
+
+// binarySearch searches the sorted list for elem and returns the leftmost position where it is or would be, and
+// whether it was found.
+func binarySearch(list []any, elem any) (int, bool, error) {
+	var err error
+	i, found := slices.BinarySearchFunc(list, elem, func(e, target any) int {
+		c, cerr := compareOrdered(e, target)
+		if cerr != nil && err == nil {
+			err = cerr
+		}
+		return c
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	return i, found, nil
+}
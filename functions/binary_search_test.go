@@ -0,0 +1,108 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinarySearch(t *testing.T) {
+	env := map[string]any{
+		"ports": []int{22, 80, 443, 8080},
+		"names": []string{"alice", "bob", "carol"},
+	}
+
+	tests := []struct {
+		name           string
+		exp            string
+		want           int
+		wantRuntimeErr bool
+	}{
+		{
+			name: "found",
+			exp:  `binarySearch([1, 3, 5, 7, 9], 7)`,
+			want: 3,
+		},
+		{
+			name: "found first",
+			exp:  `binarySearch([1, 3, 5, 7, 9], 1)`,
+			want: 0,
+		},
+		{
+			name: "not found",
+			exp:  `binarySearch([1, 3, 5, 7, 9], 4)`,
+			want: -1,
+		},
+		{
+			name: "not found past the end",
+			exp:  `binarySearch([1, 3, 5, 7, 9], 10)`,
+			want: -1,
+		},
+		{
+			name: "first of equal elements",
+			exp:  `binarySearch([1, 2, 2, 2, 3], 2)`,
+			want: 1,
+		},
+		{
+			name: "int found among floats",
+			exp:  `binarySearch([1.5, 2.0, 2.5], 2)`,
+			want: 1,
+		},
+		{
+			name: "strings",
+			exp:  `binarySearch(["a", "c", "e"], "c")`,
+			want: 1,
+		},
+		{
+			name: "typed ints from the environment",
+			exp:  `binarySearch(ports, 443)`,
+			want: 2,
+		},
+		{
+			name: "typed strings from the environment",
+			exp:  `binarySearch(names, "dave")`,
+			want: -1,
+		},
+		{
+			name: "empty list",
+			exp:  `binarySearch([], 1)`,
+			want: -1,
+		},
+		{
+			name:           "string in numbers",
+			exp:            `binarySearch([1, 2, 3], "2")`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), BinarySearch())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// CartesianProduct provides the cartesianProduct function as an Expr function. It returns every combination that
+// takes one element from each of the given lists, in order, as a list of tuples. The size of the result is the product
+// of the list lengths, so a result whose tuples and their elements would exceed the Expr VM's memory budget,
+// vm.MemoryBudget, is an error.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.CartesianProduct())
+//
+// Expression:
+//
+//	cartesianProduct([1, 2], ["a", "b"]) // [[1, "a"], [1, "b"], [2, "a"], [2, "b"]]
+//	cartesianProduct([1], [2], [3, 4])   // [[1, 2, 3], [1, 2, 4]]
+func CartesianProduct() expr.Option {
+	return expr.Function("cartesianProduct", func(params ...any) (any, error) {
+		if len(params) == 0 {
+			return nil, fmt.Errorf("expected at least one parameter")
+		}
+		// Every parameter is checked before an empty list ends the product early.
+		lists := make([][]any, len(params))
+		for i, p := range params {
+			l, err := toList(p)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %d: %w", i+1, err)
+			}
+			lists[i] = l
+		}
+		for _, l := range lists {
+			if len(l) == 0 {
+				return []any{}, nil
+			}
+		}
+
+		// Each combination costs the budget one element for itself and one for each of its members.
+		budget := memoryBudget()
+		size := 1
+		for _, l := range lists {
+			if size > budget/(len(lists)+1)/len(l) {
+				return nil, fmt.Errorf("result would exceed the memory budget of %d elements", budget)
+			}
+			size *= len(l)
+		}
+
+		out := make([]any, 0, size)
+		idx := make([]int, len(lists))
+		for {
+			tuple := make([]any, len(lists))
+			for i, l := range lists {
+				tuple[i] = l[idx[i]]
+			}
+			out = append(out, tuple)
+
+			// Advance the rightmost index, carrying into the indices to its left.
+			i := len(idx) - 1
+			for ; i >= 0; i-- {
+				idx[i]++
+				if idx[i] < len(lists[i]) {
+					break
+				}
+				idx[i] = 0
+			}
+			if i < 0 {
+				return out, nil
+			}
+		}
+	},
+		new(func(...any) ([]any, error)),
+	)
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCartesianProduct(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           []any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "two small lists",
+			exp:  `cartesianProduct([1, 2], ["a", "b"])`,
+			want: []any{[]any{1, "a"}, []any{1, "b"}, []any{2, "a"}, []any{2, "b"}},
+		},
+		{
+			name: "three lists",
+			exp:  `cartesianProduct([1], [2, 3], [4, 5])`,
+			want: []any{[]any{1, 2, 4}, []any{1, 2, 5}, []any{1, 3, 4}, []any{1, 3, 5}},
+		},
+		{
+			name: "single list",
+			exp:  `cartesianProduct([1, 2])`,
+			want: []any{[]any{1}, []any{2}},
+		},
+		{
+			name: "typed lists from the environment",
+			exp:  `cartesianProduct(regions, tiers)`,
+			want: []any{[]any{"us", 1}, []any{"us", 2}, []any{"eu", 1}, []any{"eu", 2}},
+		},
+		{
+			name: "an empty list yields no combinations",
+			exp:  `cartesianProduct([1, 2], [])`,
+			want: []any{},
+		},
+		{
+			name:           "size cap",
+			exp:            `cartesianProduct(1..1000, 1..1000)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "not a list",
+			exp:            `cartesianProduct([1], 2)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "not a list after an empty list",
+			exp:            `cartesianProduct([], 2)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name: "an empty list is checked before the size cap",
+			exp:  `cartesianProduct(1..1000, 1..1000, [])`,
+			want: []any{},
+		},
+		{
+			name:           "no lists",
+			exp:            `cartesianProduct()`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{"regions": []string{"us", "eu"}, "tiers": []int{1, 2}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), CartesianProduct())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestCartesianProductMemoryBudget(t *testing.T) {
+	budget := vm.MemoryBudget
+	t.Cleanup(func() { vm.MemoryBudget = budget })
+	vm.MemoryBudget = 17
+
+	program, err := expr.Compile(`cartesianProduct([1, 2, 3], [4, 5])`, CartesianProduct())
+	require.NoError(t, err)
+	_, err = expr.Run(program, nil)
+	require.ErrorContains(t, err, "result would exceed the memory budget of 17 elements")
+
+	program, err = expr.Compile(`cartesianProduct([1, 2], [4, 5])`, CartesianProduct())
+	require.NoError(t, err)
+	got, err := expr.Run(program, nil)
+	require.NoError(t, err)
+	assert.Len(t, got, 4)
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/expr-lang/expr"
+)
+
+// CharClass provides the isASCII and isPrintable functions as Expr functions. isASCII reports whether every character
+// in a string is ASCII, and isPrintable reports whether every character is printable as defined by unicode.IsPrint.
+// Both return true for an empty string.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.CharClass())
+//
+// Expression:
+//
+//	isASCII("hello")        // true
+//	isASCII("héllo")        // false
+//	isPrintable("héllo")    // true
+//	isPrintable("a\u0000b") // false
+func CharClass() expr.Option {
+	return options(
+		expr.Function("isASCII", func(params ...any) (any, error) {
+			if len(params) != 1 {
+				return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+			}
+			s, err := arg[string](params, 0)
+			if err != nil {
+				return nil, err
+			}
+			return !strings.ContainsFunc(s, func(r rune) bool { return r > unicode.MaxASCII }), nil
+		},
+			new(func(string) bool),
+		),
+		expr.Function("isPrintable", func(params ...any) (any, error) {
+			if len(params) != 1 {
+				return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+			}
+			s, err := arg[string](params, 0)
+			if err != nil {
+				return nil, err
+			}
+			return !strings.ContainsFunc(s, func(r rune) bool { return !unicode.IsPrint(r) }), nil
+		},
+			new(func(string) bool),
+		),
+	)
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharClass(t *testing.T) {
+	tests := []struct {
+		name          string
+		in            string
+		wantASCII     bool
+		wantPrintable bool
+	}{
+		{
+			name:          "empty",
+			in:            "",
+			wantASCII:     true,
+			wantPrintable: true,
+		},
+		{
+			name:          "pure ascii",
+			in:            "Hello, World! 123",
+			wantASCII:     true,
+			wantPrintable: true,
+		},
+		{
+			name:          "multibyte",
+			in:            "héllo 世界 😀",
+			wantASCII:     false,
+			wantPrintable: true,
+		},
+		{
+			name:          "control characters",
+			in:            "bell\a and nul\x00",
+			wantASCII:     true,
+			wantPrintable: false,
+		},
+		{
+			name:          "tab is not printable",
+			in:            "a\tb",
+			wantASCII:     true,
+			wantPrintable: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			env := map[string]any{"s": tc.in}
+			program, err := expr.Compile(`[isASCII(s), isPrintable(s)]`, expr.Env(env), CharClass())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			require.NoError(t, err)
+			assert.Equal(t, []any{tc.wantASCII, tc.wantPrintable}, got)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// Classify provides the classify function as an Expr function. Given a map of label to lower bound, it returns the
+// label with the highest bound that does not exceed x. When x is below every bound the optional default label is
+// returned, or an empty string if no default is given. If two labels share a bound the lexically smaller label wins so
+// that the result does not depend on map iteration order.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Classify())
+//
+// Expression:
+//
+//	classify(85, {"A": 90, "B": 80, "C": 70})      // "B"
+//	classify(42, {"A": 90, "B": 80, "C": 70}, "F") // "F"
+func Classify() expr.Option {
+	return expr.Function("classify", func(params ...any) (any, error) {
+		if len(params) < 2 || len(params) > 3 {
+			return nil, fmt.Errorf("expected two or three parameters, got %d", len(params))
+		}
+		x, err := argFloat(params, 0)
+		if err != nil {
+			return nil, err
+		}
+		thresholds, err := arg[map[string]any](params, 1)
+		if err != nil {
+			return nil, err
+		}
+		var def string
+		if len(params) == 3 {
+			if def, err = arg[string](params, 2); err != nil {
+				return nil, err
+			}
+		}
+		return classify(x, thresholds, def)
+	},
+		new(func(float64, map[string]any) (string, error)),
+		new(func(float64, map[string]any, string) (string, error)),
+	)
+}
+
+// classify returns the label of the highest threshold not exceeding x, or def if there is none.
+func classify(x float64, thresholds map[string]any, def string) (string, error) {
+	label, found := def, false
+	var best float64
+	for l, v := range thresholds {
+		bound, err := toFloat(v)
+		if err != nil {
+			return "", fmt.Errorf("threshold %q: %w", l, err)
+		}
+		if bound > x {
+			continue
+		}
+		if !found || bound > best || (bound == best && l < label) {
+			label, best, found = l, bound, true
+		}
+	}
+	return label, nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           string
+		wantRuntimeErr bool
+	}{
+		{
+			name: "top grade",
+			exp:  `classify(97, grades)`,
+			want: "A",
+		},
+		{
+			name: "middle grade",
+			exp:  `classify(85, grades)`,
+			want: "B",
+		},
+		{
+			name: "exactly on a bound",
+			exp:  `classify(70, grades)`,
+			want: "C",
+		},
+		{
+			name: "fractional score",
+			exp:  `classify(79.9, grades)`,
+			want: "C",
+		},
+		{
+			name: "below all thresholds with default",
+			exp:  `classify(42, grades, "F")`,
+			want: "F",
+		},
+		{
+			name: "below all thresholds without default",
+			exp:  `classify(42, grades)`,
+			want: "",
+		},
+		{
+			name: "integer score from the environment",
+			exp:  `classify(score, {"high": 50, "low": 0})`,
+			want: "high",
+		},
+		{
+			name: "shared bound picks the smaller label",
+			exp:  `classify(5, {"b": 1, "a": 1})`,
+			want: "a",
+		},
+		{
+			name:           "non-numeric threshold",
+			exp:            `classify(5, {"a": "one"})`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{
+		"score":  64,
+		"grades": map[string]any{"A": 90, "B": 80, "C": 70, "D": 60},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Classify())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
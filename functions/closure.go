@@ -0,0 +1,130 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/file"
+	"github.com/expr-lang/expr/parser"
+)
+
+// closureCall returns the arguments of node when it is a call to the function name with n arguments, the last of
+// which is a string literal holding a closure body. The arguments before the body are returned along with the parsed
+// closure, whose nodes are all located at the string literal.
+//
+// Expr only parses closure literals such as {# > 0} as arguments to its own builtins, so custom functions that need
+// a closure accept its body as a string literal instead. A patch uses closureCall to rewrite the call into the
+// equivalent builtins at compile time, so the body is type checked and compiled along with the rest of the expression
+// and can reference variables from the surrounding environment. The registered functions are only called when the
+// body is not a string literal or does not parse, and report why with closureError.
+func closureCall(node ast.Node, name string, n int) ([]ast.Node, *ast.ClosureNode, bool) {
+	call, ok := node.(*ast.CallNode)
+	if !ok || len(call.Arguments) != n {
+		return nil, nil, false
+	}
+	if id, ok := call.Callee.(*ast.IdentifierNode); !ok || id.Value != name {
+		return nil, nil, false
+	}
+	src, ok := call.Arguments[n-1].(*ast.StringNode)
+	if !ok {
+		return nil, nil, false
+	}
+	closure, err := parseClosure(src.Value)
+	if err != nil {
+		return nil, nil, false
+	}
+	var c ast.Node = closure
+	ast.Walk(&c, locator{src.Location()})
+	return call.Arguments[:n-1], closure, true
+}
+
+// stringClosures maps each function that takes its closure body as a string literal to its number of arguments.
+var stringClosures = map[string]int{
+	"takeWhile": 2,
+	"dropWhile": 2,
+	"fold":      3,
+	"scan":      3,
+	"allMatch":  2,
+	"anyMatch":  2,
+	"noneMatch": 2,
+}
+
+// StringClosure returns the parsed closure body of node when it is a call to one of the functions that take their
+// closure body as a string literal, such as takeWhile(list, "# > 0"). It lets tools that inspect an expression
+// without compiling it see into the body.
+func StringClosure(node ast.Node) (*ast.ClosureNode, bool) {
+	for name, n := range stringClosures {
+		if _, closure, ok := closureCall(node, name, n); ok {
+			return closure, true
+		}
+	}
+	return nil, false
+}
+
+// parseClosure parses src as the body of a closure, such as the # > 0 in all(list, # > 0).
+func parseClosure(src string) (*ast.ClosureNode, error) {
+	tree, err := parser.Parse(fmt.Sprintf("reduce(nil, %s)", src))
+	if err != nil {
+		return nil, err
+	}
+	if b, ok := tree.Node.(*ast.BuiltinNode); ok && len(b.Arguments) == 2 {
+		if closure, ok := b.Arguments[1].(*ast.ClosureNode); ok {
+			return closure, nil
+		}
+	}
+	return nil, fmt.Errorf("%q is not a single expression", src)
+}
+
+// closureError returns the error for a call whose closure body, given as the last of params, was not rewritten by
+// a patch. kind names the closure in the message, such as "predicate".
+func closureError(kind string, params []any) error {
+	src, err := arg[string](params, len(params)-1)
+	if err != nil {
+		return err
+	}
+	if _, err := parseClosure(src); err != nil {
+		return fmt.Errorf("invalid %s %q: %w", kind, src, err)
+	}
+	return fmt.Errorf("the %s must be a string literal, got %q", kind, src)
+}
+
+// locator sets the location of every node it visits.
+type locator struct {
+	loc file.Location
+}
+
+func (l locator) Visit(node *ast.Node) {
+	(*node).SetLocation(l.loc)
+}
+
+// newBuiltin returns a call to the builtin name.
+func newBuiltin(name string, args ...ast.Node) *ast.BuiltinNode {
+	return &ast.BuiltinNode{Name: name, Arguments: args}
+}
+
+// newLet returns a declaration of the variable name bound to value, whose body is returned by body given a function
+// that returns a new reference to the variable. Patches bind values they use more than once so each is evaluated once,
+// under names that are not valid identifiers and so cannot clash with the expression's own.
+func newLet(name string, value ast.Node, body func(ref func() ast.Node) ast.Node) *ast.VariableDeclaratorNode {
+	return &ast.VariableDeclaratorNode{
+		Name:  name,
+		Value: value,
+		Expr: body(func() ast.Node {
+			return &ast.IdentifierNode{Value: name}
+		}),
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/expr-lang/expr"
+)
+
+// Combinatorics provides the factorial, combinations, and permutations functions as Expr functions.
+//
+// factorial(n) returns n!. combinations(n, k) returns the number of ways to choose k items from n without regard to
+// order, and permutations(n, k) the number of ordered arrangements of k items from n. Both return 0 when k > n.
+// Negative inputs are errors, as are results that overflow an int (such as any factorial above 20!).
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Combinatorics())
+//
+// Expression:
+//
+//	factorial(5)       // 120
+//	combinations(5, 2) // 10
+//	permutations(5, 2) // 20
+func Combinatorics() expr.Option {
+	return options(
+		expr.Function("factorial", func(params ...any) (any, error) {
+			if len(params) != 1 {
+				return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+			}
+			n, err := arg[int](params, 0)
+			if err != nil {
+				return nil, err
+			}
+			if n < 0 {
+				return nil, fmt.Errorf("factorial of a negative number %d", n)
+			}
+			r, ok := fallingFactorial(n, n)
+			if !ok {
+				return nil, fmt.Errorf("integer overflow: factorial(%d) does not fit in an int", n)
+			}
+			return r, nil
+		},
+			new(func(int) (int, error)),
+		),
+		expr.Function("combinations", func(params ...any) (any, error) {
+			n, k, err := nkParams(params)
+			if err != nil {
+				return nil, err
+			}
+			if k > n {
+				return 0, nil
+			}
+			r := new(big.Int).Binomial(int64(n), int64(k))
+			if !r.IsInt64() || r.Int64() > math.MaxInt {
+				return nil, fmt.Errorf("integer overflow: combinations(%d, %d) does not fit in an int", n, k)
+			}
+			return int(r.Int64()), nil
+		},
+			new(func(int, int) (int, error)),
+		),
+		expr.Function("permutations", func(params ...any) (any, error) {
+			n, k, err := nkParams(params)
+			if err != nil {
+				return nil, err
+			}
+			if k > n {
+				return 0, nil
+			}
+			r, ok := fallingFactorial(n, k)
+			if !ok {
+				return nil, fmt.Errorf("integer overflow: permutations(%d, %d) does not fit in an int", n, k)
+			}
+			return r, nil
+		},
+			new(func(int, int) (int, error)),
+		),
+	)
+}
+
+// nkParams returns the non-negative n and k parameters for combinations and permutations.
+func nkParams(params []any) (int, int, error) {
+	if len(params) != 2 {
+		return 0, 0, fmt.Errorf("expected two parameters, got %d", len(params))
+	}
+	n, err := arg[int](params, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	k, err := arg[int](params, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	if n < 0 || k < 0 {
+		return 0, 0, fmt.Errorf("n and k must not be negative, got %d and %d", n, k)
+	}
+	return n, k, nil
+}
+
+// fallingFactorial returns n * (n-1) * ... * (n-k+1), and false if the product overflows an int.
+func fallingFactorial(n, k int) (int, bool) {
+	r := 1
+	for i := n; i > n-k; i-- {
+		if r > math.MaxInt/i {
+			return 0, false
+		}
+		r *= i
+	}
+	return r, true
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombinatorics(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           int
+		wantRuntimeErr bool
+		wantErrMsg     string
+	}{
+		{
+			name: "factorial - zero",
+			exp:  `factorial(0)`,
+			want: 1,
+		},
+		{
+			name: "factorial - small",
+			exp:  `factorial(5)`,
+			want: 120,
+		},
+		{
+			name: "factorial - largest",
+			exp:  `factorial(20)`,
+			want: 2432902008176640000,
+		},
+		{
+			name:           "factorial - overflow",
+			exp:            `factorial(21)`,
+			wantRuntimeErr: true,
+			wantErrMsg:     "integer overflow: factorial(21) does not fit in an int",
+		},
+		{
+			name:           "factorial - negative",
+			exp:            `factorial(-1)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name: "combinations - small",
+			exp:  `combinations(5, 2)`,
+			want: 10,
+		},
+		{
+			name: "combinations - k greater than n",
+			exp:  `combinations(2, 5)`,
+			want: 0,
+		},
+		{
+			name: "combinations - large without overflow",
+			exp:  `combinations(60, 30)`,
+			want: 118264581564861424,
+		},
+		{
+			name:           "combinations - overflow",
+			exp:            `combinations(100, 50)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "combinations - negative",
+			exp:            `combinations(-5, 2)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name: "permutations - small",
+			exp:  `permutations(5, 2)`,
+			want: 20,
+		},
+		{
+			name: "permutations - zero k",
+			exp:  `permutations(5, 0)`,
+			want: 1,
+		},
+		{
+			name:           "permutations - overflow",
+			exp:            `permutations(100, 20)`,
+			wantRuntimeErr: true,
+			wantErrMsg:     "integer overflow: permutations(100, 20) does not fit in an int",
+		},
+		{
+			name:           "permutations - negative",
+			exp:            `permutations(5, -2)`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(nil), Combinatorics())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, nil)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				require.ErrorContains(t, err, tc.wantErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/expr-lang/expr"
+)
+
+// Common provides the mostCommon, leastCommon, and topN functions as Expr functions. mostCommon and leastCommon return
+// the element that appears the most or the fewest times in a list, and topN returns the n most common elements in
+// descending order of frequency. Elements are counted the same way as frequencies, and ties are broken by whichever
+// element appears first in the list.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Common())
+//
+// Expression:
+//
+//	mostCommon(["a", "b", "b", "c"])   // "b"
+//	leastCommon(["a", "b", "b", "c"])  // "a"
+//	topN(["a", "b", "b", "c", "c"], 2) // ["b", "c"]
+func Common() expr.Option {
+	return options(
+		expr.Function("mostCommon", func(params ...any) (any, error) {
+			return pickCommon(params, func(a, b int) bool { return a > b })
+		},
+			new(func([]any) (any, error)),
+			new(func([]int) (any, error)),
+			new(func([]float64) (any, error)),
+			new(func([]string) (any, error)),
+		),
+		expr.Function("leastCommon", func(params ...any) (any, error) {
+			return pickCommon(params, func(a, b int) bool { return a < b })
+		},
+			new(func([]any) (any, error)),
+			new(func([]int) (any, error)),
+			new(func([]float64) (any, error)),
+			new(func([]string) (any, error)),
+		),
+		expr.Function("topN", func(params ...any) (any, error) {
+			if len(params) != 2 {
+				return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+			}
+			list, err := toList(params[0])
+			if err != nil {
+				return nil, err
+			}
+			n, err := arg[int](params, 1)
+			if err != nil {
+				return nil, err
+			}
+			if n < 0 {
+				return nil, fmt.Errorf("n must not be negative, got %d", n)
+			}
+			counts := tally(list)
+			sort.SliceStable(counts, func(i, j int) bool { return counts[i].count > counts[j].count })
+			out := make([]any, 0, min(n, len(counts)))
+			for _, c := range counts[:min(n, len(counts))] {
+				out = append(out, c.value)
+			}
+			return out, nil
+		},
+			new(func([]any, int) ([]any, error)),
+			new(func([]int, int) ([]any, error)),
+			new(func([]float64, int) ([]any, error)),
+			new(func([]string, int) ([]any, error)),
+		),
+	)
+}
+
+// counted is an element of a list and the number of times it appears.
+type counted struct {
+	value any
+	count int
+}
+
+// tally counts the elements of list, keyed as frequencies does, in order of first appearance.
+func tally(list []any) []counted {
+	var out []counted
+	index := make(map[string]int)
+	for _, v := range list {
+		k := frequencyKey(v)
+		if i, ok := index[k]; ok {
+			out[i].count++
+			continue
+		}
+		index[k] = len(out)
+		out = append(out, counted{value: v, count: 1})
+	}
+	return out
+}
+
+// pickCommon returns the first element of the list in params whose count is preferred over every other count by
+// better.
+func pickCommon(params []any, better func(a, b int) bool) (any, error) {
+	if len(params) != 1 {
+		return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+	}
+	list, err := toList(params[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("list is empty")
+	}
+	counts := tally(list)
+	best := counts[0]
+	for _, c := range counts[1:] {
+		if better(c.count, best.count) {
+			best = c
+		}
+	}
+	return best.value, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommon(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "most common clear winner",
+			exp:  `mostCommon(["GET", "POST", "GET", "GET", "DELETE"])`,
+			want: "GET",
+		},
+		{
+			name: "least common clear winner",
+			exp:  `leastCommon(["GET", "POST", "GET", "POST", "DELETE"])`,
+			want: "DELETE",
+		},
+		{
+			name: "most common tie goes to first appearance",
+			exp:  `mostCommon([3, 1, 1, 3, 2])`,
+			want: 3,
+		},
+		{
+			name: "least common tie goes to first appearance",
+			exp:  `leastCommon(["a", "b", "c", "a"])`,
+			want: "b",
+		},
+		{
+			name: "typed list from the environment",
+			exp:  `mostCommon(codes)`,
+			want: 500,
+		},
+		{
+			name: "top n",
+			exp:  `topN(["a", "b", "b", "c", "c", "c", "d"], 2)`,
+			want: []any{"c", "b"},
+		},
+		{
+			name: "top n ties keep first appearance order",
+			exp:  `topN(["x", "y", "z", "y", "x"], 3)`,
+			want: []any{"x", "y", "z"},
+		},
+		{
+			name: "top n larger than the distinct count",
+			exp:  `topN([1, 1, 2], 5)`,
+			want: []any{1, 2},
+		},
+		{
+			name: "top zero",
+			exp:  `topN([1, 2], 0)`,
+			want: []any{},
+		},
+		{
+			name:           "most common of an empty list",
+			exp:            `mostCommon([])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "least common of an empty list",
+			exp:            `leastCommon([])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "negative n",
+			exp:            `topN([1], -1)`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{"codes": []int{200, 500, 500, 404}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Common())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
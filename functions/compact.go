@@ -0,0 +1,77 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/expr-lang/expr"
+)
+
+// Compact provides the compact function as an Expr function. It returns a copy of a list with nil elements, empty
+// strings, and empty lists and maps removed, preserving the order of the rest. Passing false as the second argument
+// removes only nil elements.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Compact())
+//
+// Expression:
+//
+//	compact([1, nil, "", [], "a"])        // [1, "a"]
+//	compact([1, nil, "", [], "a"], false) // [1, "", [], "a"]
+func Compact() expr.Option {
+	return expr.Function("compact", func(params ...any) (any, error) {
+		if len(params) < 1 || len(params) > 2 {
+			return nil, fmt.Errorf("expected one or two parameters, got %d", len(params))
+		}
+		list, err := toList(params[0])
+		if err != nil {
+			return nil, err
+		}
+		removeEmpty := true
+		if len(params) == 2 {
+			if removeEmpty, err = arg[bool](params, 1); err != nil {
+				return nil, err
+			}
+		}
+
+		out := make([]any, 0, len(list))
+		for _, v := range list {
+			if v == nil || (removeEmpty && isEmpty(v)) {
+				continue
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	},
+		new(func([]any) ([]any, error)),
+		new(func([]any, bool) ([]any, error)),
+		new(func([]string) ([]any, error)),
+		new(func([]string, bool) ([]any, error)),
+	)
+}
+
+// isEmpty reports whether v is an empty string, list, or map.
+func isEmpty(v any) bool {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len() == 0
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompact(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want []any
+	}{
+		{
+			name: "nils, empty strings, and empty collections removed",
+			exp:  `compact([1, nil, "", "a", [], [2], {}, {"b": 3}, nil])`,
+			want: []any{1, "a", []any{2}, map[string]any{"b": 3}},
+		},
+		{
+			name: "only nils removed",
+			exp:  `compact([1, nil, "", [], {}, nil], false)`,
+			want: []any{1, "", []any{}, map[string]any{}},
+		},
+		{
+			name: "zero values that are not empty are kept",
+			exp:  `compact([0, false, " "])`,
+			want: []any{0, false, " "},
+		},
+		{
+			name: "sparse data from the environment",
+			exp:  `compact(tags)`,
+			want: []any{"prod", "eu"},
+		},
+		{
+			name: "empty list",
+			exp:  `compact([])`,
+			want: []any{},
+		},
+	}
+
+	env := map[string]any{"tags": []string{"prod", "", "eu", ""}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Compact())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
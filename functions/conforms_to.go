@@ -0,0 +1,109 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/expr-lang/expr"
+)
+
+// ConformsTo provides the conformsTo and missingFields functions as Expr functions. conformsTo reports whether every
+// one of a list of dotted paths leads to a value that is not nil in a map, and missingFields returns the paths that do
+// not, in the order they were given. As with flattenKeys, list elements are addressed by their index.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.ConformsTo())
+//
+// Expression:
+//
+//	conformsTo({"spec": {"replicas": 3}}, ["spec.replicas"])                  // true
+//	missingFields({"spec": {"replicas": 3}}, ["spec.replicas", "spec.image"]) // ["spec.image"]
+//	conformsTo({"ports": [{"port": 80}]}, ["ports.0.port"])                   // true
+func ConformsTo() expr.Option {
+	return options(
+		expr.Function("conformsTo", func(params ...any) (any, error) {
+			missing, err := missingFieldsParams(params)
+			if err != nil {
+				return nil, err
+			}
+			return len(missing) == 0, nil
+		},
+			new(func(map[string]any, []any) (bool, error)),
+			new(func(map[string]any, []string) (bool, error)),
+		),
+		expr.Function("missingFields", func(params ...any) (any, error) {
+			return missingFieldsParams(params)
+		},
+			new(func(map[string]any, []any) ([]string, error)),
+			new(func(map[string]any, []string) ([]string, error)),
+		),
+	)
+}
+
+// missingFieldsParams returns the paths in the second parameter that are missing from the map in the first.
+func missingFieldsParams(params []any) ([]string, error) {
+	if len(params) != 2 {
+		return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+	}
+	m, err := arg[map[string]any](params, 0)
+	if err != nil {
+		return nil, err
+	}
+	required, err := toList(params[1])
+	if err != nil {
+		return nil, err
+	}
+	missing := make([]string, 0)
+	for i, p := range required {
+		path, ok := p.(string)
+		if !ok {
+			return nil, fmt.Errorf("element %d: expected a string path, got %T", i, p)
+		}
+		if v, ok := lookupPath(m, path); !ok || v == nil {
+			missing = append(missing, path)
+		}
+	}
+	return missing, nil
+}
+
+// lookupPath returns the value at the dotted path in m, and whether it exists.
+func lookupPath(m map[string]any, path string) (any, bool) {
+	var v any = m
+	for _, segment := range strings.Split(path, ".") {
+		switch c := v.(type) {
+		case map[string]any:
+			var ok bool
+			if v, ok = c[segment]; !ok {
+				return nil, false
+			}
+		default:
+			list, err := toList(c)
+			if err != nil {
+				return nil, false
+			}
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(list) {
+				return nil, false
+			}
+			v = list[i]
+		}
+	}
+	return v, true
+}
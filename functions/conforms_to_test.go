@@ -0,0 +1,110 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConformsTo(t *testing.T) {
+	env := map[string]any{
+		"pod": map[string]any{
+			"metadata": map[string]any{"name": "web", "namespace": nil},
+			"spec": map[string]any{
+				"containers": []any{
+					map[string]any{"name": "nginx", "image": "nginx:1.25"},
+				},
+			},
+		},
+		"required": []string{"metadata.name", "spec.containers.0.image"},
+	}
+
+	tests := []struct {
+		name    string
+		exp     string
+		want    any
+		wantErr bool
+	}{
+		{
+			name: "all fields present",
+			exp:  `conformsTo(pod, ["metadata.name", "spec.containers.0.image"])`,
+			want: true,
+		},
+		{
+			name: "typed list of paths",
+			exp:  `conformsTo(pod, required)`,
+			want: true,
+		},
+		{
+			name: "no required fields",
+			exp:  `conformsTo(pod, [])`,
+			want: true,
+		},
+		{
+			name: "missing nested field",
+			exp:  `conformsTo(pod, ["metadata.name", "spec.containers.0.resources.limits"])`,
+			want: false,
+		},
+		{
+			name: "nil field is missing",
+			exp:  `conformsTo(pod, ["metadata.namespace"])`,
+			want: false,
+		},
+		{
+			name: "list index out of range",
+			exp:  `conformsTo(pod, ["spec.containers.1.name"])`,
+			want: false,
+		},
+		{
+			name: "path through a scalar",
+			exp:  `conformsTo(pod, ["metadata.name.first"])`,
+			want: false,
+		},
+		{
+			name: "missing fields in order",
+			exp:  `missingFields(pod, ["spec.replicas", "metadata.name", "metadata.namespace", "status"])`,
+			want: []string{"spec.replicas", "metadata.namespace", "status"},
+		},
+		{
+			name: "no missing fields",
+			exp:  `missingFields(pod, required)`,
+			want: []string{},
+		},
+		{
+			name:    "path that is not a string",
+			exp:     `conformsTo(pod, ["metadata.name", 1])`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), ConformsTo())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/expr-lang/expr"
+)
+
+// ControlChars provides the containsControlChars function as an Expr function. It reports whether a string contains any
+// control characters, such as NUL, escape, or delete. Whitespace control characters (tab, line feed, vertical tab,
+// form feed, and carriage return) are ignored unless the optional second argument is true.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.ControlChars())
+//
+// Expression:
+//
+//	containsControlChars("hello")      // false
+//	containsControlChars("a\u0000b")   // true
+//	containsControlChars("a\tb")       // false
+//	containsControlChars("a\tb", true) // true
+func ControlChars() expr.Option {
+	return expr.Function("containsControlChars", func(params ...any) (any, error) {
+		if len(params) < 1 || len(params) > 2 {
+			return nil, fmt.Errorf("expected one or two parameters, got %d", len(params))
+		}
+		s, err := arg[string](params, 0)
+		if err != nil {
+			return nil, err
+		}
+		var includeWhitespace bool
+		if len(params) == 2 {
+			if includeWhitespace, err = arg[bool](params, 1); err != nil {
+				return nil, err
+			}
+		}
+		return containsControlChars(s, includeWhitespace), nil
+	},
+		new(func(string) bool),
+		new(func(string, bool) bool),
+	)
+}
+
+// containsControlChars reports whether s contains a control character. Whitespace control characters are only
+// considered when includeWhitespace is true.
+func containsControlChars(s string, includeWhitespace bool) bool {
+	return strings.ContainsFunc(s, func(r rune) bool {
+		if !unicode.IsControl(r) {
+			return false
+		}
+		return includeWhitespace || !unicode.IsSpace(r)
+	})
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestControlChars(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []any // Results without and with whitespace included.
+	}{
+		{
+			name: "clean",
+			in:   "GET /index.html 200",
+			want: []any{false, false},
+		},
+		{
+			name: "nul byte",
+			in:   "admin\x00.php",
+			want: []any{true, true},
+		},
+		{
+			name: "escape sequence",
+			in:   "\x1b[31mred",
+			want: []any{true, true},
+		},
+		{
+			name: "whitespace only",
+			in:   "line one\r\n\tline two",
+			want: []any{false, true},
+		},
+		{
+			name: "printable unicode",
+			in:   "héllo 世界",
+			want: []any{false, false},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			env := map[string]any{"s": tc.in}
+			program, err := expr.Compile(`[containsControlChars(s), containsControlChars(s, true)]`, expr.Env(env), ControlChars())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
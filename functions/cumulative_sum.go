@@ -0,0 +1,91 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// CumSum provides the cumulativeSum function as an Expr function. It returns the running totals of a numeric list.
+// When every element is an integer the totals are integers, otherwise all of the totals are floats.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.CumSum())
+//
+// Expression:
+//
+//	cumulativeSum([1, 2, 3])   // [1, 3, 6]
+//	cumulativeSum([1, 2.5, 3]) // [1.0, 3.5, 6.5]
+func CumSum() expr.Option {
+	return expr.Function("cumulativeSum", func(params ...any) (any, error) {
+		if len(params) != 1 {
+			return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+		}
+		list, err := toList(params[0])
+		if err != nil {
+			return nil, err
+		}
+		return cumulativeSum(list)
+	},
+		new(func([]any) ([]any, error)),
+		new(func([]int) ([]any, error)),
+		new(func([]float64) ([]any, error)),
+	)
+}
+
+// cumulativeSum returns the running totals of list, as ints if every element is an integer and as float64 otherwise.
+func cumulativeSum(list []any) ([]any, error) {
+	allInts := true
+	for i, e := range list {
+		if _, err := toFloat(e); err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		if !isInteger(e) {
+			allInts = false
+		}
+	}
+
+	out := make([]any, len(list))
+	if allInts {
+		var total int
+		for i, e := range list {
+			n, _ := toInt(e)
+			total += n
+			out[i] = total
+		}
+		return out, nil
+	}
+
+	var total float64
+	for i, e := range list {
+		f, _ := toFloat(e)
+		total += f
+		out[i] = total
+	}
+	return out, nil
+}
+
+// isInteger reports whether v holds one of the Go integer types.
+func isInteger(v any) bool {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCumSum(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           []any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "all ints",
+			exp:  `cumulativeSum([1, 2, 3, -4])`,
+			want: []any{1, 3, 6, 2},
+		},
+		{
+			name: "int slice",
+			exp:  `cumulativeSum(ints)`,
+			want: []any{10, 30, 60},
+		},
+		{
+			name: "mixed int and float",
+			exp:  `cumulativeSum([1, 2.5, 3])`,
+			want: []any{1.0, 3.5, 6.5},
+		},
+		{
+			name: "empty",
+			exp:  `cumulativeSum([])`,
+			want: []any{},
+		},
+		{
+			name:           "non-numeric element",
+			exp:            `cumulativeSum([1, "2"])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "nil element",
+			exp:            `cumulativeSum([1, nil])`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{"ints": []int{10, 20, 30}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), CumSum())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
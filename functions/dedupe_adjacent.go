@@ -0,0 +1,57 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// DedupeAdjacent provides the dedupeAdjacent function as an Expr function. It collapses each run of consecutive equal
+// elements into a single element. Unlike a distinct operation, duplicates that are not next to each other are kept.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.DedupeAdjacent())
+//
+// Expression:
+//
+//	dedupeAdjacent([1, 1, 2, 2, 2, 1]) // [1, 2, 1]
+func DedupeAdjacent() expr.Option {
+	return expr.Function("dedupeAdjacent", func(params ...any) (any, error) {
+		if len(params) != 1 {
+			return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+		}
+		list, err := toList(params[0])
+		if err != nil {
+			return nil, err
+		}
+		out := make([]any, 0, len(list))
+		for i, v := range list {
+			if i > 0 && equal(v, list[i-1]) {
+				continue
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	},
+		new(func([]any) ([]any, error)),
+		new(func([]int) ([]any, error)),
+		new(func([]float64) ([]any, error)),
+		new(func([]string) ([]any, error)),
+	)
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedupeAdjacent(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want []any
+	}{
+		{
+			name: "runs collapsed",
+			exp:  `dedupeAdjacent(["info", "info", "info", "warn", "warn", "error", "error", "error"])`,
+			want: []any{"info", "warn", "error"},
+		},
+		{
+			name: "non-adjacent duplicates preserved",
+			exp:  `dedupeAdjacent([1, 2, 1, 2, 2, 1])`,
+			want: []any{1, 2, 1, 2, 1},
+		},
+		{
+			name: "numbers compare by value",
+			exp:  `dedupeAdjacent([1, 1.0, 2])`,
+			want: []any{1, 2},
+		},
+		{
+			name: "typed list from the environment",
+			exp:  `dedupeAdjacent(levels)`,
+			want: []any{"ok", "fail", "ok"},
+		},
+		{
+			name: "empty list",
+			exp:  `dedupeAdjacent([])`,
+			want: []any{},
+		},
+	}
+
+	env := map[string]any{"levels": []string{"ok", "ok", "fail", "ok", "ok"}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), DedupeAdjacent())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/expr-lang/expr"
+)
+
+// Divisors provides the gcd and lcm functions as Expr functions. gcd returns the greatest common divisor and lcm the
+// least common multiple of one or more integers. Both results are non-negative. lcm returns an error instead of
+// silently wrapping when the result does not fit in an int.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Divisors())
+//
+// Expression:
+//
+//	gcd(12, 18)    // 6
+//	gcd(12, 18, 8) // 2
+//	lcm(4, 6)      // 12
+//	lcm(2, 3, 4)   // 12
+func Divisors() expr.Option {
+	return options(
+		expr.Function("gcd", func(params ...any) (any, error) {
+			ints, err := intParams(params)
+			if err != nil {
+				return nil, err
+			}
+			r := 0
+			for _, n := range ints {
+				if r, err = gcd(r, n); err != nil {
+					return nil, err
+				}
+			}
+			return r, nil
+		},
+			new(func(int, ...int) (int, error)),
+		),
+		expr.Function("lcm", func(params ...any) (any, error) {
+			ints, err := intParams(params)
+			if err != nil {
+				return nil, err
+			}
+			r := 1
+			for _, n := range ints {
+				if r, err = lcm(r, n); err != nil {
+					return nil, err
+				}
+			}
+			return r, nil
+		},
+			new(func(int, ...int) (int, error)),
+		),
+	)
+}
+
+// intParams returns params as ints, requiring at least one.
+func intParams(params []any) ([]int, error) {
+	if len(params) == 0 {
+		return nil, fmt.Errorf("expected at least one parameter")
+	}
+	ints := make([]int, len(params))
+	for i := range params {
+		n, err := arg[int](params, i)
+		if err != nil {
+			return nil, err
+		}
+		ints[i] = n
+	}
+	return ints, nil
+}
+
+// absInt returns the absolute value of n, or an error for math.MinInt which has no positive counterpart.
+func absInt(n int) (int, error) {
+	if n == math.MinInt {
+		return 0, fmt.Errorf("integer overflow: |%d| does not fit in an int", n)
+	}
+	if n < 0 {
+		return -n, nil
+	}
+	return n, nil
+}
+
+// gcd returns the greatest common divisor of a and b using the Euclidean algorithm.
+func gcd(a, b int) (int, error) {
+	a, err := absInt(a)
+	if err != nil {
+		return 0, err
+	}
+	b, err = absInt(b)
+	if err != nil {
+		return 0, err
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a, nil
+}
+
+// lcm returns the least common multiple of a and b, or an error if it overflows an int.
+func lcm(a, b int) (int, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	g, err := gcd(a, b)
+	if err != nil {
+		return 0, err
+	}
+	a, _ = absInt(a)
+	b, _ = absInt(b)
+	x := a / g
+	if x > math.MaxInt/b {
+		return 0, fmt.Errorf("integer overflow: lcm(%d, %d) does not fit in an int", a, b)
+	}
+	return x * b, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDivisors(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           int
+		wantCompileErr bool
+		wantRuntimeErr bool
+	}{
+		{
+			name: "gcd - coprime",
+			exp:  `gcd(9, 28)`,
+			want: 1,
+		},
+		{
+			name: "lcm - coprime",
+			exp:  `lcm(9, 28)`,
+			want: 252,
+		},
+		{
+			name: "gcd - common factor",
+			exp:  `gcd(12, 18)`,
+			want: 6,
+		},
+		{
+			name: "lcm - common factor",
+			exp:  `lcm(12, 18)`,
+			want: 36,
+		},
+		{
+			name: "gcd - negative",
+			exp:  `gcd(-12, 18)`,
+			want: 6,
+		},
+		{
+			name: "gcd - zero",
+			exp:  `gcd(0, 5)`,
+			want: 5,
+		},
+		{
+			name: "lcm - zero",
+			exp:  `lcm(0, 5)`,
+			want: 0,
+		},
+		{
+			name: "gcd - variadic",
+			exp:  `gcd(24, 36, 60, 84)`,
+			want: 12,
+		},
+		{
+			name: "lcm - variadic",
+			exp:  `lcm(2, 3, 4, 5)`,
+			want: 60,
+		},
+		{
+			name: "gcd - single",
+			exp:  `gcd(-7)`,
+			want: 7,
+		},
+		{
+			name:           "lcm - overflow",
+			exp:            `lcm(4611686018427387903, 4611686018427387902)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "no arguments",
+			exp:            `gcd()`,
+			wantCompileErr: true,
+		},
+		{
+			name:           "float argument",
+			exp:            `lcm(1.5, 2)`,
+			wantCompileErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(nil), Divisors())
+			if tc.wantCompileErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, nil)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/expr-lang/expr"
+)
+
+// ENSOrAddress provides the isENSOrAddress function as an Expr function. It reports whether a string is either a
+// syntactically valid ENS name or a well-formed Ethereum address, as accepted by isWeb3Address. Given a list, it
+// reports whether every element is one or the other, so an empty list is vacuously true, as it is for all.
+//
+// ENS names are checked for shape only: two or more dot separated labels, each made of lowercase letters, digits, and
+// hyphens, without a leading or trailing hyphen. Names are not resolved, and the full ENS normalization rules are not
+// applied, so uppercase names are rejected rather than folded.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.ENSOrAddress())
+//
+// Expression:
+//
+//	isENSOrAddress("vitalik.eth")                                // true
+//	isENSOrAddress("0x52908400098527886e0f7030069857d2e4169ee7") // true
+//	isENSOrAddress("vitalik")                                    // false
+func ENSOrAddress() expr.Option {
+	return expr.Function("isENSOrAddress", func(params ...any) (any, error) {
+		if len(params) != 1 {
+			return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+		}
+		return allStrings(params[0], func(s string) bool {
+			return isENSName(s) || isWeb3Address(s)
+		})
+	},
+		new(func(string) (bool, error)),
+		new(func([]any) (bool, error)),
+		new(func([]string) (bool, error)),
+	)
+}
+
+// isENSName reports whether s has the shape of an ENS name: two or more dot separated labels of lowercase letters,
+// digits, and hyphens, with no label starting or ending with a hyphen.
+func isENSName(s string) bool {
+	if len(s) > 255 {
+		return false
+	}
+	labels := strings.Split(s, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, r := range label {
+			if r != '-' && !unicode.IsDigit(r) && !(unicode.IsLetter(r) && !unicode.IsUpper(r)) {
+				return false
+			}
+		}
+	}
+	return true
+}
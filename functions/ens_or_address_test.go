@@ -0,0 +1,130 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestENSOrAddress(t *testing.T) {
+	env := map[string]any{
+		"mixed":   []any{"vitalik.eth", "0x52908400098527886e0f7030069857d2e4169ee7"},
+		"invalid": []any{"vitalik.eth", "vitalik"},
+		"numbers": []any{"vitalik.eth", 42},
+		"empty":   []any{},
+	}
+
+	tests := []struct {
+		name           string
+		exp            string
+		want           bool
+		wantRuntimeErr bool
+	}{
+		{
+			name: "ens name",
+			exp:  `isENSOrAddress("vitalik.eth")`,
+			want: true,
+		},
+		{
+			name: "ens subdomain",
+			exp:  `isENSOrAddress("pay.my-wallet.eth")`,
+			want: true,
+		},
+		{
+			name: "non-ascii ens name",
+			exp:  `isENSOrAddress("bücher.eth")`,
+			want: true,
+		},
+		{
+			name: "address",
+			exp:  `isENSOrAddress("0x52908400098527886e0f7030069857d2e4169ee7")`,
+			want: true,
+		},
+		{
+			name: "neither",
+			exp:  `isENSOrAddress("vitalik")`,
+			want: false,
+		},
+		{
+			name: "short address",
+			exp:  `isENSOrAddress("0x5290840009852788")`,
+			want: false,
+		},
+		{
+			name: "uppercase ens name",
+			exp:  `isENSOrAddress("Vitalik.eth")`,
+			want: false,
+		},
+		{
+			name: "empty label",
+			exp:  `isENSOrAddress("vitalik..eth")`,
+			want: false,
+		},
+		{
+			name: "leading hyphen",
+			exp:  `isENSOrAddress("-vitalik.eth")`,
+			want: false,
+		},
+		{
+			name: "invalid character",
+			exp:  `isENSOrAddress("vit alik.eth")`,
+			want: false,
+		},
+		{
+			name: "list of names and addresses",
+			exp:  `isENSOrAddress(mixed)`,
+			want: true,
+		},
+		{
+			name: "list with an invalid entry",
+			exp:  `isENSOrAddress(invalid)`,
+			want: false,
+		},
+		{
+			name: "string list",
+			exp:  `isENSOrAddress(["vitalik.eth", "nick.eth"])`,
+			want: true,
+		},
+		{
+			name: "empty list is vacuously true",
+			exp:  `isENSOrAddress(empty)`,
+			want: true,
+		},
+		{
+			name:           "list with a non-string",
+			exp:            `isENSOrAddress(numbers)`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), ENSOrAddress())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"math"
+	"unicode/utf8"
+
+	"github.com/expr-lang/expr"
+)
+
+// minSecretLength is the minimum number of characters a string must have before looksLikeSecret will consider it.
+// Short strings have too few characters for their entropy to be meaningful.
+const minSecretLength = 16
+
+// Entropy provides the shannonEntropy and looksLikeSecret functions as Expr functions. shannonEntropy returns the
+// Shannon entropy of a string in bits per character, calculated over runes. looksLikeSecret reports whether a string
+// is at least 16 characters long and has an entropy greater than the given threshold, which is useful for spotting
+// tokens and keys that have leaked into logs.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Entropy())
+//
+// Expression:
+//
+//	shannonEntropy("aaaa")                           // 0
+//	shannonEntropy("abcd")                           // 2
+//	looksLikeSecret("ghp_x8Kq2LmZ9vR4tW7nB3cY", 4.0) // true
+func Entropy() expr.Option {
+	return options(
+		expr.Function("shannonEntropy", func(params ...any) (any, error) {
+			if len(params) != 1 {
+				return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+			}
+			s, err := arg[string](params, 0)
+			if err != nil {
+				return nil, err
+			}
+			return shannonEntropy(s), nil
+		},
+			new(func(string) float64),
+		),
+		expr.Function("looksLikeSecret", func(params ...any) (any, error) {
+			if len(params) != 2 {
+				return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+			}
+			s, err := arg[string](params, 0)
+			if err != nil {
+				return nil, err
+			}
+			threshold, err := argFloat(params, 1)
+			if err != nil {
+				return nil, err
+			}
+			return utf8.RuneCountInString(s) >= minSecretLength && shannonEntropy(s) > threshold, nil
+		},
+			new(func(string, float64) (bool, error)),
+		),
+	)
+}
+
+// shannonEntropy returns the Shannon entropy of s in bits per rune. An empty string has an entropy of 0.
+func shannonEntropy(s string) float64 {
+	counts := make(map[rune]int)
+	var total int
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+	if total == 0 {
+		return 0
+	}
+
+	var entropy float64
+	for _, c := range counts {
+		p := float64(c) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"math"
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_shannonEntropy(t *testing.T) {
+	assert.Equal(t, 0.0, shannonEntropy(""))
+	assert.Equal(t, 0.0, shannonEntropy("aaaa"))
+	assert.Equal(t, 2.0, shannonEntropy("abcd"))
+	// Multibyte characters are counted once each.
+	assert.Equal(t, 1.0, shannonEntropy("éé😀😀"))
+	assert.InDelta(t, math.Log2(24), shannonEntropy("ghp_x8Kq2LmZ9vR4tW7nB3cY"), 1e-9)
+}
+
+func TestEntropy(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want any
+	}{
+		{
+			name: "low entropy word",
+			exp:  `shannonEntropy("aaaaaaaa") < 1`,
+			want: true,
+		},
+		{
+			name: "low entropy word is not a secret",
+			exp:  `looksLikeSecret("passwordpassword", 3.5)`,
+			want: false,
+		},
+		{
+			name: "high entropy token",
+			exp:  `shannonEntropy("ghp_x8Kq2LmZ9vR4tW7nB3cY") > 4`,
+			want: true,
+		},
+		{
+			name: "high entropy token is a secret",
+			exp:  `looksLikeSecret("ghp_x8Kq2LmZ9vR4tW7nB3cY", 4)`,
+			want: true,
+		},
+		{
+			name: "high entropy but too short",
+			exp:  `looksLikeSecret("x8Kq2LmZ", 2.5)`,
+			want: false,
+		},
+		{
+			name: "empty",
+			exp:  `shannonEntropy("")`,
+			want: 0.0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(nil), Entropy())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
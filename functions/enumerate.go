@@ -0,0 +1,56 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// Enumerate provides the enumerate function as an Expr function. It pairs each element of a list with its position,
+// returning a list of maps with "index" and "value" keys. This makes the position available inside closures such as
+// map and filter.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Enumerate())
+//
+// Expression:
+//
+//	enumerate(["a", "b"])                                   // [{"index": 0, "value": "a"}, {"index": 1, "value": "b"}]
+//	map(filter(enumerate(items), .index % 2 == 0), .value) // every other item
+func Enumerate() expr.Option {
+	return expr.Function("enumerate", func(params ...any) (any, error) {
+		if len(params) != 1 {
+			return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+		}
+		list, err := toList(params[0])
+		if err != nil {
+			return nil, err
+		}
+		out := make([]any, len(list))
+		for i, v := range list {
+			out[i] = map[string]any{"index": i, "value": v}
+		}
+		return out, nil
+	},
+		new(func([]any) ([]any, error)),
+		new(func([]int) ([]any, error)),
+		new(func([]float64) ([]any, error)),
+		new(func([]string) ([]any, error)),
+	)
+}
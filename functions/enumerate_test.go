@@ -0,0 +1,63 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumerate(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want any
+	}{
+		{
+			name: "index and value pairing",
+			exp:  `enumerate(["a", "b", "c"])`,
+			want: []any{
+				map[string]any{"index": 0, "value": "a"},
+				map[string]any{"index": 1, "value": "b"},
+				map[string]any{"index": 2, "value": "c"},
+			},
+		},
+		{
+			name: "positions in a closure",
+			exp:  `map(filter(enumerate(steps), .index % 2 == 0), .value)`,
+			want: []any{"build", "deploy"},
+		},
+		{
+			name: "empty list",
+			exp:  `enumerate([])`,
+			want: []any{},
+		},
+	}
+
+	env := map[string]any{"steps": []string{"build", "test", "deploy"}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Enumerate())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import "reflect"
+
+// equal reports whether two values are equal the way Expr's == operator treats them: numbers compare by value
+// regardless of their Go type, so 1 and 1.0 are equal, and lists and maps compare element by element.
+func equal(a, b any) bool {
+	if fa, err := toFloat(a); err == nil {
+		fb, err := toFloat(b)
+		return err == nil && fa == fb
+	}
+
+	ra, rb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !ra.IsValid() || !rb.IsValid() {
+		return ra.IsValid() == rb.IsValid()
+	}
+	switch {
+	case isListKind(ra.Kind()) && isListKind(rb.Kind()):
+		if ra.Len() != rb.Len() {
+			return false
+		}
+		for i := 0; i < ra.Len(); i++ {
+			if !equal(ra.Index(i).Interface(), rb.Index(i).Interface()) {
+				return false
+			}
+		}
+		return true
+	case ra.Kind() == reflect.Map && rb.Kind() == reflect.Map:
+		if ra.Len() != rb.Len() || ra.Type().Key() != rb.Type().Key() {
+			return false
+		}
+		for _, k := range ra.MapKeys() {
+			vb := rb.MapIndex(k)
+			if !vb.IsValid() || !equal(ra.MapIndex(k).Interface(), vb.Interface()) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func isListKind(k reflect.Kind) bool {
+	return k == reflect.Slice || k == reflect.Array
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b any
+		want bool
+	}{
+		{name: "same ints", a: 1, b: 1, want: true},
+		{name: "int and float", a: 1, b: 1.0, want: true},
+		{name: "different numbers", a: 1, b: 2, want: false},
+		{name: "number and string", a: 1, b: "1", want: false},
+		{name: "strings", a: "a", b: "a", want: true},
+		{name: "nils", a: nil, b: nil, want: true},
+		{name: "nil and value", a: nil, b: 0, want: false},
+		{name: "mixed numeric lists", a: []any{1, 2}, b: []int{1, 2}, want: true},
+		{name: "lists of different lengths", a: []any{1}, b: []any{1, 2}, want: false},
+		{name: "maps", a: map[string]any{"a": 1}, b: map[string]any{"a": 1.0}, want: true},
+		{name: "maps with different keys", a: map[string]any{"a": 1}, b: map[string]any{"b": 1}, want: false},
+		{name: "list and map", a: []any{}, b: map[string]any{}, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, equal(tc.a, tc.b))
+			assert.Equal(t, tc.want, equal(tc.b, tc.a))
+		})
+	}
+}
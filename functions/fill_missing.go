@@ -0,0 +1,72 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// FillMissing provides the fillMissing function as an Expr function. It returns a copy of a list of maps in which
+// every map that lacks the given key has it set to a default value. Maps that already have the key, including those
+// where it is nil, and elements that are not maps are left as they are. The input list and its maps are not modified.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.FillMissing())
+//
+// Expression:
+//
+//	fillMissing([{"a": 1}, {}], "a", 0) // [{"a": 1}, {"a": 0}]
+func FillMissing() expr.Option {
+	return expr.Function("fillMissing", func(params ...any) (any, error) {
+		if len(params) != 3 {
+			return nil, fmt.Errorf("expected three parameters, got %d", len(params))
+		}
+		list, err := toList(params[0])
+		if err != nil {
+			return nil, err
+		}
+		key, err := arg[string](params, 1)
+		if err != nil {
+			return nil, err
+		}
+		def := params[2]
+
+		out := make([]any, len(list))
+		for i, v := range list {
+			m, ok := v.(map[string]any)
+			if !ok {
+				out[i] = v
+				continue
+			}
+			if _, ok := m[key]; ok {
+				out[i] = m
+				continue
+			}
+			filled := make(map[string]any, len(m)+1)
+			for k, mv := range m {
+				filled[k] = mv
+			}
+			filled[key] = def
+			out[i] = filled
+		}
+		return out, nil
+	},
+		new(func([]any, string, any) ([]any, error)),
+	)
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFillMissing(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want any
+	}{
+		{
+			name: "some elements lack the key",
+			exp:  `fillMissing([{"name": "a", "replicas": 3}, {"name": "b"}], "replicas", 1)`,
+			want: []any{
+				map[string]any{"name": "a", "replicas": 3},
+				map[string]any{"name": "b", "replicas": 1},
+			},
+		},
+		{
+			name: "nil values are present",
+			exp:  `fillMissing([{"a": nil}], "a", 0)`,
+			want: []any{map[string]any{"a": nil}},
+		},
+		{
+			name: "non-map elements are unchanged",
+			exp:  `fillMissing([1, {}], "a", "x")`,
+			want: []any{1, map[string]any{"a": "x"}},
+		},
+		{
+			name: "normalized for comparison",
+			exp:  `all(fillMissing(services, "tls", false), .tls == false)`,
+			want: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			env := map[string]any{
+				"services": []any{map[string]any{"tls": false}, map[string]any{}},
+			}
+			program, err := expr.Compile(tc.exp, expr.Env(env), FillMissing())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFillMissingInputUnchanged(t *testing.T) {
+	services := []any{map[string]any{"name": "a"}}
+	env := map[string]any{"services": services}
+
+	program, err := expr.Compile(`fillMissing(services, "tls", true)`, expr.Env(env), FillMissing())
+	require.NoError(t, err)
+
+	got, err := expr.Run(program, env)
+	require.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{"name": "a", "tls": true}}, got)
+	assert.Equal(t, []any{map[string]any{"name": "a"}}, services)
+}
@@ -0,0 +1,62 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+)
+
+// FindLast provides the findLast and findLastIndex functions. Expr already ships both as builtins that accept a
+// closure, and custom functions cannot take closure arguments, so rather than shadowing them this option makes sure
+// the builtins are enabled, even when they were disabled by an earlier option such as expr.DisableAllBuiltins().
+// findLast returns the last element matching the predicate, or nil if none match.
+//
+// Because they are builtins, both take a closure literal such as # > 0, like filter and find. The functions this
+// package adds itself, such as takeWhile and fold, take the closure body as a string literal instead.
+//
+// The builtin findLastIndex returns nil when nothing matches. This option changes it to return -1 instead, so its
+// result can always be compared as an int. Other builtins, findIndex included, are left as they are.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.FindLast())
+//
+// Expression:
+//
+//	findLast([1, 2, 3, 4], # % 2 == 1)      // 3
+//	findLastIndex([1, 2, 3, 4], # % 2 == 1) // 2
+//	findLastIndex([1, 2, 3, 4], # > 5)      // -1
+func FindLast() expr.Option {
+	return options(
+		expr.EnableBuiltin("findLast"),
+		expr.EnableBuiltin("findLastIndex"),
+		expr.Patch(findLastIndexPatch{}),
+	)
+}
+
+// findLastIndexPatch rewrites findLastIndex(...) as findLastIndex(...) ?? -1.
+type findLastIndexPatch struct{}
+
+func (findLastIndexPatch) Visit(node *ast.Node) {
+	if b, ok := (*node).(*ast.BuiltinNode); ok && b.Name == "findLastIndex" {
+		ast.Patch(node, &ast.BinaryNode{
+			Operator: "??",
+			Left:     b,
+			Right:    &ast.IntegerNode{Value: -1},
+		})
+	}
+}
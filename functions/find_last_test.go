@@ -0,0 +1,87 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindLast(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want any
+	}{
+		{
+			name: "match near the end",
+			exp:  `findLast([1, 2, 3, 10, 4], # > 5)`,
+			want: 10,
+		},
+		{
+			name: "index of a match near the end",
+			exp:  `findLastIndex([1, 2, 3, 10, 4], # > 5)`,
+			want: 3,
+		},
+		{
+			name: "multiple matches return the last",
+			exp:  `findLast(events, .level == "error").id`,
+			want: 3,
+		},
+		{
+			name: "index of the last of multiple matches",
+			exp:  `findLastIndex(events, .level == "error")`,
+			want: 2,
+		},
+		{
+			name: "no match",
+			exp:  `findLast([1, 2, 3], # > 5)`,
+			want: nil,
+		},
+		{
+			name: "index with no match",
+			exp:  `findLastIndex([1, 2, 3], # > 5)`,
+			want: -1,
+		},
+		{
+			name: "findIndex is left unchanged",
+			exp:  `findIndex([1, 2, 3], # > 5) == nil`,
+			want: true,
+		},
+	}
+
+	env := map[string]any{
+		"events": []any{
+			map[string]any{"id": 1, "level": "error"},
+			map[string]any{"id": 2, "level": "info"},
+			map[string]any{"id": 3, "level": "error"},
+			map[string]any{"id": 4, "level": "info"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := []expr.Option{expr.Env(env), expr.DisableAllBuiltins(), expr.EnableBuiltin("findIndex"), FindLast()}
+			program, err := expr.Compile(tc.exp, opts...)
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
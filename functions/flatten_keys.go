@@ -0,0 +1,167 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/expr-lang/expr"
+)
+
+// FlattenKeys provides the flattenKeys and unflattenKeys functions as Expr functions. flattenKeys turns a nested map
+// into a single-level map whose keys are the dotted paths to each leaf value, with list elements addressed by their
+// index. Empty maps and lists are kept as leaves. unflattenKeys reverses it, turning any level whose keys are exactly
+// 0 to n-1 back into a list. Keys that themselves contain dots cannot be told apart from nesting, so flattenKeys
+// returns an error when two paths flatten to the same key.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.FlattenKeys())
+//
+// Expression:
+//
+//	flattenKeys({"a": {"b": 1}, "c": [2, 3]})     // {"a.b": 1, "c.0": 2, "c.1": 3}
+//	unflattenKeys({"a.b": 1, "c.0": 2, "c.1": 3}) // {"a": {"b": 1}, "c": [2, 3]}
+func FlattenKeys() expr.Option {
+	return options(
+		expr.Function("flattenKeys", func(params ...any) (any, error) {
+			if len(params) != 1 {
+				return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+			}
+			m, err := arg[map[string]any](params, 0)
+			if err != nil {
+				return nil, err
+			}
+			out := make(map[string]any)
+			for k, v := range m {
+				if err := flatten(k, v, out); err != nil {
+					return nil, err
+				}
+			}
+			return out, nil
+		},
+			new(func(map[string]any) (map[string]any, error)),
+		),
+		expr.Function("unflattenKeys", func(params ...any) (any, error) {
+			if len(params) != 1 {
+				return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+			}
+			m, err := arg[map[string]any](params, 0)
+			if err != nil {
+				return nil, err
+			}
+			return unflatten(m)
+		},
+			new(func(map[string]any) (map[string]any, error)),
+		),
+	)
+}
+
+// flatten adds v to out under path, descending into non-empty maps and lists. It returns an error if path is already
+// in out, since which value would be kept then depends on map iteration order.
+func flatten(path string, v any, out map[string]any) error {
+	if m, ok := v.(map[string]any); ok && len(m) > 0 {
+		for k, mv := range m {
+			if err := flatten(path+"."+k, mv, out); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if l, err := toList(v); err == nil && len(l) > 0 {
+		for i, lv := range l {
+			if err := flatten(path+"."+strconv.Itoa(i), lv, out); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if _, ok := out[path]; ok {
+		return fmt.Errorf("key %q is produced by more than one path", path)
+	}
+	out[path] = v
+	return nil
+}
+
+// node is a level of the structure being rebuilt by unflatten, kept distinct from maps that are leaf values.
+type node map[string]any
+
+// unflatten rebuilds the nested structure described by the dotted keys of m.
+func unflatten(m map[string]any) (map[string]any, error) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	root := make(node)
+	for _, k := range keys {
+		segments := strings.Split(k, ".")
+		n := root
+		for i, s := range segments[:len(segments)-1] {
+			child, ok := n[s]
+			if !ok {
+				child = make(node)
+				n[s] = child
+			}
+			next, ok := child.(node)
+			if !ok {
+				return nil, fmt.Errorf("key %q conflicts with %q", k, strings.Join(segments[:i+1], "."))
+			}
+			n = next
+		}
+		last := segments[len(segments)-1]
+		if _, ok := n[last]; ok {
+			return nil, fmt.Errorf("key %q conflicts with a nested key", k)
+		}
+		n[last] = m[k]
+	}
+
+	out := make(map[string]any, len(root))
+	for k, v := range root {
+		out[k] = restore(v)
+	}
+	return out, nil
+}
+
+// restore converts a node into a list if its keys are exactly 0 to n-1, or into a map otherwise. Other values are
+// returned as they are.
+func restore(v any) any {
+	n, ok := v.(node)
+	if !ok {
+		return v
+	}
+	list := make([]any, len(n))
+	for i := range list {
+		lv, ok := n[strconv.Itoa(i)]
+		if !ok {
+			list = nil
+			break
+		}
+		list[i] = restore(lv)
+	}
+	if list != nil {
+		return list
+	}
+	out := make(map[string]any, len(n))
+	for k, nv := range n {
+		out[k] = restore(nv)
+	}
+	return out
+}
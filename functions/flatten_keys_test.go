@@ -0,0 +1,116 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlattenKeys(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "flatten nested object",
+			exp:  `flattenKeys(config)`,
+			want: map[string]any{
+				"server.host":        "localhost",
+				"server.port":        8080,
+				"server.tls.enabled": true,
+				"users.0.name":       "alice",
+				"users.1.name":       "bob",
+				"users.1.roles.0":    "admin",
+				"features":           []any{},
+				"server.tls.ciphers": map[string]any{},
+			},
+		},
+		{
+			name: "round trip",
+			exp:  `unflattenKeys(flattenKeys(config)) == config`,
+			want: true,
+		},
+		{
+			name: "unflatten rebuilds lists",
+			exp:  `unflattenKeys({"a.b": 1, "c.0": 2, "c.1": 3})`,
+			want: map[string]any{"a": map[string]any{"b": 1}, "c": []any{2, 3}},
+		},
+		{
+			name: "non-contiguous indices stay a map",
+			exp:  `unflattenKeys({"c.0": 2, "c.2": 3})`,
+			want: map[string]any{"c": map[string]any{"0": 2, "2": 3}},
+		},
+		{
+			name: "leaf maps are not converted",
+			exp:  `unflattenKeys({"a": {"0": 1}})`,
+			want: map[string]any{"a": map[string]any{"0": 1}},
+		},
+		{
+			name: "config diffing",
+			exp:  `len(filter(keys(flattenKeys(config)), # startsWith "server.tls."))`,
+			want: 2,
+		},
+		{
+			name:           "dotted key collides with nested key",
+			exp:            `flattenKeys({"a.b": 1, "a": {"b": 2}})`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "dotted key collides with list index",
+			exp:            `flattenKeys({"a.0": 1, "a": [2]})`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "leaf conflicts with nested key",
+			exp:            `unflattenKeys({"a": 1, "a.b": 2})`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{
+		"config": map[string]any{
+			"server": map[string]any{
+				"host": "localhost",
+				"port": 8080,
+				"tls":  map[string]any{"enabled": true, "ciphers": map[string]any{}},
+			},
+			"users": []any{
+				map[string]any{"name": "alice"},
+				map[string]any{"name": "bob", "roles": []any{"admin"}},
+			},
+			"features": []any{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), FlattenKeys())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+)
+
+// Fold provides the fold and scan functions as Expr functions. fold left-folds a list into a single value by
+// repeatedly combining an accumulator, starting from an initial value, with each element. scan performs the same fold
+// but returns every intermediate accumulator.
+//
+// Expr reserves closure literals for its builtins, so the reducer is given as a string literal holding the closure
+// body, where #acc refers to the accumulator and # to the current element, just as they do for reduce. The call is
+// compiled into the reduce builtin, so the reducer can reference other variables.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Fold())
+//
+// Expression:
+//
+//	fold([1, 2, 3], 0, "#acc + #") // 6
+//	scan([1, 2, 3], 0, "#acc + #") // [1, 3, 6]
+func Fold() expr.Option {
+	return options(
+		expr.Function("fold", func(params ...any) (any, error) {
+			return nil, closureError("reducer", params)
+		},
+			new(func([]any, any, string) (any, error)),
+			new(func([]int, any, string) (any, error)),
+			new(func([]float64, any, string) (any, error)),
+			new(func([]string, any, string) (any, error)),
+		),
+		expr.Function("scan", func(params ...any) (any, error) {
+			return nil, closureError("reducer", params)
+		},
+			new(func([]any, any, string) ([]any, error)),
+			new(func([]int, any, string) ([]any, error)),
+			new(func([]float64, any, string) ([]any, error)),
+			new(func([]string, any, string) ([]any, error)),
+		),
+		expr.Patch(foldPatch{}),
+	)
+}
+
+// foldPatch rewrites fold(list, initial, "body") as reduce(list, body, initial), and scan(list, initial, "body") as
+// map(list, reduce(list[:#index + 1], body, initial)) with list and initial each evaluated once.
+type foldPatch struct{}
+
+func (foldPatch) Visit(node *ast.Node) {
+	if args, closure, ok := closureCall(*node, "fold", 3); ok {
+		ast.Patch(node, newBuiltin("reduce", args[0], closure, args[1]))
+		return
+	}
+	args, closure, ok := closureCall(*node, "scan", 3)
+	if !ok {
+		return
+	}
+	ast.Patch(node, newLet("scan#list", args[0], func(list func() ast.Node) ast.Node {
+		return newLet("scan#initial", args[1], func(initial func() ast.Node) ast.Node {
+			prefix := &ast.SliceNode{
+				Node: list(),
+				To: &ast.BinaryNode{
+					Operator: "+",
+					Left:     &ast.PointerNode{Name: "index"},
+					Right:    &ast.IntegerNode{Value: 1},
+				},
+			}
+			return newBuiltin("map", list(), &ast.ClosureNode{
+				Node: newBuiltin("reduce", prefix, closure, initial()),
+			})
+		})
+	}))
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "fold running sum",
+			exp:  `fold([1, 2, 3, 4], 0, "#acc + #")`,
+			want: 10,
+		},
+		{
+			name: "scan running sum",
+			exp:  `scan([1, 2, 3, 4], 0, "#acc + #")`,
+			want: []any{1, 3, 6, 10},
+		},
+		{
+			name: "fold agrees with the last scan step",
+			exp:  `fold(amounts, 0, "#acc + #") == last(scan(amounts, 0, "#acc + #"))`,
+			want: true,
+		},
+		{
+			name: "fold with a different accumulator type",
+			exp:  `fold(["a", "b", "c"], "", "#acc + upper(#)")`,
+			want: "ABC",
+		},
+		{
+			name: "scan running maximum",
+			exp:  `scan([3, 1, 4, 1, 5], 0, "max(#acc, #)")`,
+			want: []any{3, 3, 4, 4, 5},
+		},
+		{
+			name: "fold of an empty list returns the initial value",
+			exp:  `fold([], 42, "#acc + #")`,
+			want: 42,
+		},
+		{
+			name: "scan of an empty list",
+			exp:  `scan([], 0, "#acc + #")`,
+			want: []any{},
+		},
+		{
+			name:           "invalid reducer",
+			exp:            `fold([1], 0, "#acc +")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name: "reducer references a variable",
+			exp:  `fold(amounts, 0, "#acc + # * rate")`,
+			want: 60,
+		},
+		{
+			name: "scan reducer references a let variable",
+			exp:  `let step = 10; scan([1, 2], 0, "#acc + # + step")`,
+			want: []any{11, 23},
+		},
+		{
+			name: "fold with a pipe",
+			exp:  `amounts | fold(0, "#acc + #")`,
+			want: 30,
+		},
+		{
+			name:           "reducer is not a literal",
+			exp:            `fold([1], 0, "#acc" + " + #")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "reducer fails on an element",
+			exp:            `fold([1, "two"], 0, "#acc + #")`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{"amounts": []int{5, 10, 15}, "rate": 2}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Fold())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/expr-lang/expr"
+)
+
+// FormatList provides the formatList function as an Expr function. It renders a list as an English phrase using an
+// Oxford comma, such as "a, b, and c". Two elements are joined without a comma ("a and b"), and a single element is
+// returned as is. The optional second argument replaces the "and" conjunction, for example with "or". Elements that
+// are not strings are rendered with fmt.Sprint.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.FormatList())
+//
+// Expression:
+//
+//	formatList(["a", "b", "c"])  // "a, b, and c"
+//	formatList(["a", "b"], "or") // "a or b"
+func FormatList() expr.Option {
+	return expr.Function("formatList", func(params ...any) (any, error) {
+		if len(params) < 1 || len(params) > 2 {
+			return nil, fmt.Errorf("expected one or two parameters, got %d", len(params))
+		}
+		list, err := toList(params[0])
+		if err != nil {
+			return nil, err
+		}
+		conjunction := "and"
+		if len(params) == 2 {
+			if conjunction, err = arg[string](params, 1); err != nil {
+				return nil, err
+			}
+		}
+		return formatList(list, conjunction), nil
+	},
+		new(func([]any) (string, error)),
+		new(func([]any, string) (string, error)),
+		new(func([]string) (string, error)),
+		new(func([]string, string) (string, error)),
+	)
+}
+
+// formatList joins list into an English phrase using conjunction before the final element.
+func formatList(list []any, conjunction string) string {
+	items := make([]string, len(list))
+	for i, e := range list {
+		items[i] = fmt.Sprint(e)
+	}
+
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + " " + conjunction + " " + items[1]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + ", " + conjunction + " " + items[len(items)-1]
+}
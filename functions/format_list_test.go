@@ -0,0 +1,93 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatList(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want string
+	}{
+		{
+			name: "zero elements",
+			exp:  `formatList([])`,
+			want: "",
+		},
+		{
+			name: "one element",
+			exp:  `formatList(["a"])`,
+			want: "a",
+		},
+		{
+			name: "two elements",
+			exp:  `formatList(["a", "b"])`,
+			want: "a and b",
+		},
+		{
+			name: "three elements",
+			exp:  `formatList(["a", "b", "c"])`,
+			want: "a, b, and c",
+		},
+		{
+			name: "custom conjunction",
+			exp:  `formatList(["a", "b", "c"], "or")`,
+			want: "a, b, or c",
+		},
+		{
+			name: "custom conjunction - two elements",
+			exp:  `formatList(["a", "b"], "or")`,
+			want: "a or b",
+		},
+		{
+			name: "non-string elements",
+			exp:  `formatList([80, 443])`,
+			want: "80 and 443",
+		},
+		{
+			name: "string slice",
+			exp:  `formatList(registries)`,
+			want: "docker.io, gcr.io, and quay.io",
+		},
+	}
+
+	env := map[string]any{"registries": []string{"docker.io", "gcr.io", "quay.io"}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), FormatList())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFormatListNil(t *testing.T) {
+	env := map[string]any{"registries": nil}
+	program, err := expr.Compile(`formatList(registries)`, expr.Env(env), FormatList())
+	require.NoError(t, err)
+
+	_, err = expr.Run(program, env)
+	require.ErrorContains(t, err, "expected a list, got nil")
+}
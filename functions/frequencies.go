@@ -0,0 +1,66 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// Frequencies provides the frequencies function as an Expr function. It tallies how many times each element appears
+// in a list and returns a map of element to count. Non-string elements are keyed by their fmt.Sprint form, so 1 and
+// "1" share a key.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Frequencies())
+//
+// Expression:
+//
+//	frequencies(["a", "b", "a"]) // {"a": 2, "b": 1}
+//	frequencies([1, 2, 2])       // {"1": 1, "2": 2}
+func Frequencies() expr.Option {
+	return expr.Function("frequencies", func(params ...any) (any, error) {
+		if len(params) != 1 {
+			return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+		}
+		list, err := toList(params[0])
+		if err != nil {
+			return nil, err
+		}
+		out := make(map[string]any)
+		for _, v := range list {
+			k := frequencyKey(v)
+			n, _ := out[k].(int)
+			out[k] = n + 1
+		}
+		return out, nil
+	},
+		new(func([]any) (map[string]any, error)),
+		new(func([]int) (map[string]any, error)),
+		new(func([]float64) (map[string]any, error)),
+		new(func([]string) (map[string]any, error)),
+	)
+}
+
+// frequencyKey returns the map key used to tally v.
+func frequencyKey(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrequencies(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want any
+	}{
+		{
+			name: "repeated strings",
+			exp:  `frequencies(["GET", "POST", "GET", "GET", "DELETE"])`,
+			want: map[string]any{"GET": 3, "POST": 1, "DELETE": 1},
+		},
+		{
+			name: "ints",
+			exp:  `frequencies([200, 404, 200, 500, 200])`,
+			want: map[string]any{"200": 3, "404": 1, "500": 1},
+		},
+		{
+			name: "typed list from the environment",
+			exp:  `frequencies(codes)`,
+			want: map[string]any{"1": 2, "2": 1},
+		},
+		{
+			name: "tally a log field",
+			exp:  `frequencies(map(logs, .level))["error"] >= 2`,
+			want: true,
+		},
+		{
+			name: "empty list",
+			exp:  `frequencies([])`,
+			want: map[string]any{},
+		},
+	}
+
+	env := map[string]any{
+		"codes": []int{1, 2, 1},
+		"logs": []any{
+			map[string]any{"level": "error"},
+			map[string]any{"level": "info"},
+			map[string]any{"level": "error"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Frequencies())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/expr-lang/expr"
+	"golang.org/x/crypto/sha3"
+)
+
+// FunctionSelector provides the functionSelector function as an Expr function. It returns the 4-byte Ethereum
+// function selector for a function signature, the first 4 bytes of the Keccak-256 hash of the canonical signature,
+// as a "0x" prefixed hex string. Whitespace is removed from the signature before hashing, so "f(uint256, bool)" and
+// "f(uint256,bool)" have the same selector. Parameter types are not otherwise normalized, so aliases such as "uint"
+// must be written in full as "uint256".
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.FunctionSelector())
+//
+// Expression:
+//
+//	functionSelector("transfer(address,uint256)") // "0xa9059cbb"
+func FunctionSelector() expr.Option {
+	return expr.Function("functionSelector", func(params ...any) (any, error) {
+		if len(params) != 1 {
+			return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+		}
+		signature, err := arg[string](params, 0)
+		if err != nil {
+			return nil, err
+		}
+		canonical := strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) {
+				return -1
+			}
+			return r
+		}, signature)
+		if err := validateSignature(canonical); err != nil {
+			return nil, fmt.Errorf("invalid signature %q: %w", signature, err)
+		}
+
+		h := sha3.NewLegacyKeccak256()
+		h.Write([]byte(canonical))
+		return "0x" + hex.EncodeToString(h.Sum(nil)[:4]), nil
+	},
+		new(func(string) (string, error)),
+	)
+}
+
+// validateSignature checks that s is a function name followed by a parenthesized parameter list with balanced
+// parentheses.
+func validateSignature(s string) error {
+	open := strings.IndexByte(s, '(')
+	if open <= 0 {
+		return fmt.Errorf("expected a function name followed by a parameter list")
+	}
+	for i, r := range s[:open] {
+		if !(r == '_' || r == '$' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return fmt.Errorf("invalid function name %q", s[:open])
+		}
+	}
+
+	depth := 0
+	for i, r := range s[open:] {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && open+i != len(s)-1 {
+				return fmt.Errorf("unexpected characters after the parameter list")
+			}
+		}
+	}
+	if depth != 0 {
+		return fmt.Errorf("unbalanced parentheses")
+	}
+	return nil
+}
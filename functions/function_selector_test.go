@@ -0,0 +1,93 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunctionSelector(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           string
+		wantRuntimeErr bool
+	}{
+		{
+			name: "transfer",
+			exp:  `functionSelector("transfer(address,uint256)")`,
+			want: "0xa9059cbb",
+		},
+		{
+			name: "transferFrom",
+			exp:  `functionSelector("transferFrom(address,address,uint256)")`,
+			want: "0x23b872dd",
+		},
+		{
+			name: "balanceOf",
+			exp:  `functionSelector("balanceOf(address)")`,
+			want: "0x70a08231",
+		},
+		{
+			name: "no parameters",
+			exp:  `functionSelector("totalSupply()")`,
+			want: "0x18160ddd",
+		},
+		{
+			name: "whitespace is ignored",
+			exp:  `functionSelector("approve(address, uint256)")`,
+			want: "0x095ea7b3",
+		},
+		{
+			name:           "unbalanced parentheses",
+			exp:            `functionSelector("transfer(address,uint256")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "extra closing parenthesis",
+			exp:            `functionSelector("transfer(address))")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "missing parameter list",
+			exp:            `functionSelector("transfer")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "missing name",
+			exp:            `functionSelector("(address)")`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(nil), FunctionSelector())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, nil)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/expr-lang/expr"
+)
+
+// Gas provides the gweiToEther and transactionCostEther functions as Expr functions. gweiToEther converts a decimal
+// amount of gwei to ether, and transactionCostEther returns the total cost in ether of a transaction that used
+// gasUsed units of gas at a gas price given in gwei. Amounts are decimal strings and are computed with big integer
+// arithmetic in wei, so no precision is lost. Negative amounts, and gas prices finer than one wei, are an error.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Gas())
+//
+// Expression:
+//
+//	gweiToEther("1500000000")         // "1.5"
+//	transactionCostEther(21000, "30") // "0.00063"
+func Gas() expr.Option {
+	return options(
+		expr.Function("gweiToEther", func(params ...any) (any, error) {
+			if len(params) != 1 {
+				return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+			}
+			wei, err := gweiParam(params, 0)
+			if err != nil {
+				return nil, err
+			}
+			return formatDecimal(wei, weiDecimals["ether"]), nil
+		},
+			new(func(string) (string, error)),
+		),
+		expr.Function("transactionCostEther", func(params ...any) (any, error) {
+			if len(params) != 2 {
+				return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+			}
+			gasUsed, err := arg[int](params, 0)
+			if err != nil {
+				return nil, err
+			}
+			if gasUsed < 0 {
+				return nil, fmt.Errorf("gas used must not be negative, got %d", gasUsed)
+			}
+			price, err := gweiParam(params, 1)
+			if err != nil {
+				return nil, err
+			}
+			cost := new(big.Int).Mul(big.NewInt(int64(gasUsed)), price)
+			return formatDecimal(cost, weiDecimals["ether"]), nil
+		},
+			new(func(int, string) (string, error)),
+		),
+	)
+}
+
+// gweiParam returns the non-negative decimal gwei amount at index i of params, converted to wei.
+func gweiParam(params []any, i int) (*big.Int, error) {
+	s, err := arg[string](params, i)
+	if err != nil {
+		return nil, err
+	}
+	wei, err := parseDecimal(s, weiDecimals["gwei"])
+	if err != nil {
+		return nil, err
+	}
+	if wei.Sign() < 0 {
+		return nil, fmt.Errorf("gwei amount must not be negative, got %q", s)
+	}
+	return wei, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGas(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "gwei to ether",
+			exp:  `gweiToEther("1500000000")`,
+			want: "1.5",
+		},
+		{
+			name: "fractional gwei to ether",
+			exp:  `gweiToEther("0.000000001")`,
+			want: "0.000000000000000001",
+		},
+		{
+			name: "zero gwei",
+			exp:  `gweiToEther("0")`,
+			want: "0",
+		},
+		{
+			name: "simple transfer",
+			exp:  `transactionCostEther(21000, "30")`,
+			want: "0.00063",
+		},
+		{
+			name: "fractional gas price",
+			exp:  `transactionCostEther(150000, "12.5")`,
+			want: "0.001875",
+		},
+		{
+			name: "cost threshold",
+			exp:  `bigCompare(toWei(transactionCostEther(21000, "30"), "ether"), toWei("0.001", "ether")) < 0`,
+			want: true,
+		},
+		{
+			name:           "negative gwei",
+			exp:            `gweiToEther("-1")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "negative gas used",
+			exp:            `transactionCostEther(-21000, "30")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "negative gas price",
+			exp:            `transactionCostEther(21000, "-30")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "non-numeric gas price",
+			exp:            `transactionCostEther(21000, "thirty")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "gas price below one wei",
+			exp:            `gweiToEther("0.0000000001")`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(nil), Gas(), Wei(), BigInt())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, nil)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
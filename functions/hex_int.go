@@ -0,0 +1,116 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/expr-lang/expr"
+)
+
+// HexInt provides the hexToInt, hexToBigInt, and intToHex functions as Expr functions. hexToInt parses a hexadecimal
+// string, with or without a "0x" prefix, into an int, and values that do not fit in an int64 are an error.
+// hexToBigInt parses the same input without a size limit and returns the value as a decimal string, for use with the
+// BigInt functions. intToHex formats an int as a "0x" prefixed lowercase hexadecimal string. Negative values carry a
+// leading "-", as in "-0x1f", in both directions.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.HexInt())
+//
+// Expression:
+//
+//	hexToInt("0xff")                   // 255
+//	hexToBigInt("0x10000000000000000") // "18446744073709551616"
+//	intToHex(255)                      // "0xff"
+func HexInt() expr.Option {
+	return options(
+		expr.Function("hexToInt", func(params ...any) (any, error) {
+			neg, digits, err := hexParams(params)
+			if err != nil {
+				return nil, err
+			}
+			if neg {
+				digits = "-" + digits
+			}
+			n, err := strconv.ParseInt(digits, 16, 64)
+			if errors.Is(err, strconv.ErrRange) {
+				return nil, fmt.Errorf("%q overflows int", params[0])
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a hexadecimal number", params[0])
+			}
+			return int(n), nil
+		},
+			new(func(string) (int, error)),
+		),
+		expr.Function("hexToBigInt", func(params ...any) (any, error) {
+			neg, digits, err := hexParams(params)
+			if err != nil {
+				return nil, err
+			}
+			n, ok := new(big.Int).SetString(digits, 16)
+			if !ok {
+				return nil, fmt.Errorf("%q is not a hexadecimal number", params[0])
+			}
+			if neg {
+				n.Neg(n)
+			}
+			return n.String(), nil
+		},
+			new(func(string) (string, error)),
+		),
+		expr.Function("intToHex", func(params ...any) (any, error) {
+			if len(params) != 1 {
+				return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+			}
+			n, err := arg[int](params, 0)
+			if err != nil {
+				return nil, err
+			}
+			if n < 0 {
+				return "-0x" + strconv.FormatUint(-uint64(n), 16), nil
+			}
+			return "0x" + strconv.FormatUint(uint64(n), 16), nil
+		},
+			new(func(int) (string, error)),
+		),
+	)
+}
+
+// hexParams returns whether the single string parameter in params is negative, and its hexadecimal digits with the
+// sign and any "0x" prefix removed.
+func hexParams(params []any) (bool, string, error) {
+	if len(params) != 1 {
+		return false, "", fmt.Errorf("expected one parameter, got %d", len(params))
+	}
+	s, err := arg[string](params, 0)
+	if err != nil {
+		return false, "", err
+	}
+	digits, neg := strings.CutPrefix(s, "-")
+	if len(digits) >= 2 && digits[0] == '0' && (digits[1] == 'x' || digits[1] == 'X') {
+		digits = digits[2:]
+	}
+	if digits == "" || strings.ContainsAny(digits, "+-_") {
+		return false, "", fmt.Errorf("%q is not a hexadecimal number", s)
+	}
+	return neg, digits, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHexInt(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "prefixed",
+			exp:  `hexToInt("0xff")`,
+			want: 255,
+		},
+		{
+			name: "bare",
+			exp:  `hexToInt("FF")`,
+			want: 255,
+		},
+		{
+			name: "uppercase prefix",
+			exp:  `hexToInt("0X1a")`,
+			want: 26,
+		},
+		{
+			name: "negative",
+			exp:  `hexToInt("-0x1f")`,
+			want: -31,
+		},
+		{
+			name: "max int64",
+			exp:  `hexToInt("0x7fffffffffffffff")`,
+			want: 9223372036854775807,
+		},
+		{
+			name: "int to hex",
+			exp:  `intToHex(255)`,
+			want: "0xff",
+		},
+		{
+			name: "zero to hex",
+			exp:  `intToHex(0)`,
+			want: "0x0",
+		},
+		{
+			name: "negative int to hex",
+			exp:  `intToHex(-31)`,
+			want: "-0x1f",
+		},
+		{
+			name: "round trip",
+			exp:  `all([0, 1, 255, 4096, -4096, 9223372036854775807], hexToInt(intToHex(#)) == #)`,
+			want: true,
+		},
+		{
+			name: "round trip from hex",
+			exp:  `intToHex(hexToInt("0xDEADBEEF"))`,
+			want: "0xdeadbeef",
+		},
+		{
+			name: "big int",
+			exp:  `hexToBigInt("0xde0b6b3a7640000")`,
+			want: "1000000000000000000",
+		},
+		{
+			name: "big int exceeding int64",
+			exp:  `hexToBigInt("0x10000000000000000")`,
+			want: "18446744073709551616",
+		},
+		{
+			name: "negative big int",
+			exp:  `hexToBigInt("-ff")`,
+			want: "-255",
+		},
+		{
+			name:           "overflow",
+			exp:            `hexToInt("0x8000000000000000")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "not hex",
+			exp:            `hexToInt("0xzz")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "prefix only",
+			exp:            `hexToInt("0x")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "double sign",
+			exp:            `hexToInt("--1")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "big int not hex",
+			exp:            `hexToBigInt("0xg")`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(nil), HexInt())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, nil)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/expr-lang/expr"
+)
+
+// Histogram provides the histogram function as an Expr function. It counts how many values of a numeric list fall
+// into each bucket described by a sorted list of upper bounds. A value belongs to the first bucket whose upper bound
+// is greater than or equal to it, and values above the last bound are counted in an overflow bucket. The result maps
+// each bucket label ("<=bound", and ">bound" for the overflow) to its count, including buckets with a count of zero.
+// Values and bounds must be finite numbers, since NaN belongs in no bucket.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Histogram())
+//
+// Expression:
+//
+//	histogram([1, 5, 12, 80], [10, 50]) // {"<=10": 2, "<=50": 1, ">50": 1}
+func Histogram() expr.Option {
+	return expr.Function("histogram", func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		values, err := toFiniteFloats(params[0])
+		if err != nil {
+			return nil, fmt.Errorf("values: %w", err)
+		}
+		bounds, err := toFiniteFloats(params[1])
+		if err != nil {
+			return nil, fmt.Errorf("bounds: %w", err)
+		}
+		return histogram(values, bounds)
+	},
+		new(func([]any, []any) (map[string]any, error)),
+		new(func([]int, []any) (map[string]any, error)),
+		new(func([]float64, []any) (map[string]any, error)),
+	)
+}
+
+// histogram buckets values by the ascending upper bounds, with a final bucket for values above the last bound.
+func histogram(values, bounds []float64) (map[string]any, error) {
+	if len(bounds) == 0 {
+		return nil, fmt.Errorf("at least one bound is required")
+	}
+	for i := 1; i < len(bounds); i++ {
+		if bounds[i] <= bounds[i-1] {
+			return nil, fmt.Errorf("bounds must be sorted in strictly ascending order, got %v after %v", bounds[i], bounds[i-1])
+		}
+	}
+
+	labels := make([]string, len(bounds)+1)
+	for i, b := range bounds {
+		labels[i] = "<=" + strconv.FormatFloat(b, 'f', -1, 64)
+	}
+	labels[len(bounds)] = ">" + strconv.FormatFloat(bounds[len(bounds)-1], 'f', -1, 64)
+
+	counts := make([]int, len(labels))
+	for _, v := range values {
+		i := 0
+		for i < len(bounds) && v > bounds[i] {
+			i++
+		}
+		counts[i]++
+	}
+
+	out := make(map[string]any, len(labels))
+	for i, l := range labels {
+		out[l] = counts[i]
+	}
+	return out, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogram(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           map[string]any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "spread across buckets",
+			exp:  `histogram(latencies, [100, 250, 500])`,
+			want: map[string]any{"<=100": 3, "<=250": 2, "<=500": 1, ">500": 0},
+		},
+		{
+			name: "overflow value",
+			exp:  `histogram([1, 10, 10.5, 1000], [10])`,
+			want: map[string]any{"<=10": 2, ">10": 2},
+		},
+		{
+			name: "fractional bounds",
+			exp:  `histogram([0.1, 0.3, 0.7], [0.25, 0.5])`,
+			want: map[string]any{"<=0.25": 1, "<=0.5": 1, ">0.5": 1},
+		},
+		{
+			name: "empty values",
+			exp:  `histogram([], [1, 2])`,
+			want: map[string]any{"<=1": 0, "<=2": 0, ">2": 0},
+		},
+		{
+			name:           "unsorted bounds",
+			exp:            `histogram([1, 2], [10, 5])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "no bounds",
+			exp:            `histogram([1, 2], [])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "NaN value",
+			exp:            `histogram([1, 0 / 0], [10])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "infinite value",
+			exp:            `histogram([1, 1 / 0], [10])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "NaN bound",
+			exp:            `histogram([1], [0 / 0])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "non-numeric value",
+			exp:            `histogram([1, "a"], [10])`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{"latencies": []int{12, 99, 100, 101, 250, 499}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Histogram())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
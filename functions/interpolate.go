@@ -0,0 +1,93 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// Interpolate provides the interpolate function as an Expr function. It performs piecewise-linear interpolation of x
+// over the points described by the paired lists xs and ys. The xs must be sorted in ascending order. Values of x
+// outside the range of xs are clamped to the first or last y. x and the points must be finite numbers.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Interpolate())
+//
+// Expression:
+//
+//	interpolate(15, [10, 20], [1.0, 2.0]) // 1.5
+//	interpolate(5, [10, 20], [1.0, 2.0])  // 1.0
+func Interpolate() expr.Option {
+	return expr.Function("interpolate", func(params ...any) (any, error) {
+		if len(params) != 3 {
+			return nil, fmt.Errorf("expected three parameters, got %d", len(params))
+		}
+		x, err := argFiniteFloat(params, 0)
+		if err != nil {
+			return nil, err
+		}
+		xs, err := toFiniteFloats(params[1])
+		if err != nil {
+			return nil, fmt.Errorf("xs: %w", err)
+		}
+		ys, err := toFiniteFloats(params[2])
+		if err != nil {
+			return nil, fmt.Errorf("ys: %w", err)
+		}
+		return interpolate(x, xs, ys)
+	},
+		new(func(float64, []any, []any) (float64, error)),
+		new(func(float64, []int, []any) (float64, error)),
+		new(func(float64, []float64, []any) (float64, error)),
+		new(func(float64, []int, []int) (float64, error)),
+		new(func(float64, []float64, []float64) (float64, error)),
+	)
+}
+
+// interpolate returns the y value on the line between the two points whose x values surround x.
+func interpolate(x float64, xs, ys []float64) (float64, error) {
+	if len(xs) != len(ys) {
+		return 0, fmt.Errorf("got %d xs but %d ys", len(xs), len(ys))
+	}
+	if len(xs) == 0 {
+		return 0, fmt.Errorf("at least one point is required")
+	}
+	for i := 1; i < len(xs); i++ {
+		if xs[i] < xs[i-1] {
+			return 0, fmt.Errorf("xs must be sorted in ascending order: %v comes after %v", xs[i], xs[i-1])
+		}
+	}
+
+	if x <= xs[0] {
+		return ys[0], nil
+	}
+	last := len(xs) - 1
+	if x >= xs[last] {
+		return ys[last], nil
+	}
+	for i := 1; i <= last; i++ {
+		if x > xs[i] {
+			continue
+		}
+		x0, x1 := xs[i-1], xs[i]
+		y0, y1 := ys[i-1], ys[i]
+		return finite("interpolate", y0+(x-x0)*(y1-y0)/(x1-x0))
+	}
+	return ys[last], nil
+}
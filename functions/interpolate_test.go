@@ -0,0 +1,115 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolate(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           float64
+		wantRuntimeErr bool
+	}{
+		{
+			name: "interior point",
+			exp:  `interpolate(15, [10, 20, 40], [1.0, 2.0, 6.0])`,
+			want: 1.5,
+		},
+		{
+			name: "interior point in a later segment",
+			exp:  `interpolate(30, [10, 20, 40], [1.0, 2.0, 6.0])`,
+			want: 4,
+		},
+		{
+			name: "exact knot",
+			exp:  `interpolate(20, [10, 20, 40], [1.0, 2.0, 6.0])`,
+			want: 2,
+		},
+		{
+			name: "clamped below range",
+			exp:  `interpolate(-5, [10, 20, 40], [1.0, 2.0, 6.0])`,
+			want: 1,
+		},
+		{
+			name: "clamped above range",
+			exp:  `interpolate(100, [10, 20, 40], [1.0, 2.0, 6.0])`,
+			want: 6,
+		},
+		{
+			name: "tiered pricing from the environment",
+			exp:  `interpolate(usage, tiers.units, tiers.price)`,
+			want: 0.075,
+		},
+		{
+			name: "repeated knot forms a step",
+			exp:  `interpolate(10, [0, 10, 10, 20], [0, 1, 5, 6])`,
+			want: 1,
+		},
+		{
+			name:           "mismatched lengths",
+			exp:            `interpolate(1, [1, 2, 3], [1, 2])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "unsorted xs",
+			exp:            `interpolate(1, [3, 2, 1], [1, 2, 3])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "NaN x",
+			exp:            `interpolate(0 / 0, [1, 2], [1, 2])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "infinite y",
+			exp:            `interpolate(1, [1, 2], [1, 1 / 0])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "no points",
+			exp:            `interpolate(1, [], [])`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{
+		"usage": 1500,
+		"tiers": map[string]any{
+			"units": []any{1000, 2000},
+			"price": []any{0.1, 0.05},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Interpolate())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tc.want, got, 1e-9)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/expr-lang/expr"
+	"golang.org/x/crypto/sha3"
+)
+
+// IsChecksummedAddress provides the isChecksummedAddress function as an Expr function. It reports whether a string is
+// a "0x" prefixed hex address of the given total length, including the prefix, whose letter casing matches its
+// EIP-55 checksum. The same mixed-case checksum is applied to addresses of any length, so hex addresses of chains
+// other than Ethereum can be checked by passing their length; Ethereum addresses are 42 characters. Given a list, it
+// reports whether every element is a checksummed address, so an empty list is vacuously true, as it is for all. The
+// length must be even and longer than the prefix, so that there is at least one hex digit to check.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.IsChecksummedAddress())
+//
+// Expression:
+//
+//	isChecksummedAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", 42) // true
+//	isChecksummedAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", 42) // false
+func IsChecksummedAddress() expr.Option {
+	return expr.Function("isChecksummedAddress", func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		length, err := arg[int](params, 1)
+		if err != nil {
+			return nil, err
+		}
+		if length <= 2 || length%2 != 0 {
+			return nil, fmt.Errorf("length must be even and greater than 2, got %d", length)
+		}
+		return allStrings(params[0], func(s string) bool {
+			return isHexAddress(s, length) && s[1] == 'x' && s[2:] == checksum(s[2:])
+		})
+	},
+		new(func(string, int) (bool, error)),
+		new(func([]any, int) (bool, error)),
+		new(func([]string, int) (bool, error)),
+	)
+}
+
+// checksum returns the hex digits with EIP-55 checksum casing: each letter is uppercased when the matching nibble of
+// the Keccak-256 hash of the lowercase digits is 8 or more.
+func checksum(digits string) string {
+	lower := strings.ToLower(digits)
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(lower))
+	hash := hex.EncodeToString(h.Sum(nil))
+
+	out := []byte(lower)
+	for i, c := range out {
+		// The hash has 64 nibbles, so digits past that keep their lowercase form.
+		if c >= 'a' && i < len(hash) && hash[i] >= '8' {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return string(out)
+}
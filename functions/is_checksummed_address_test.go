@@ -0,0 +1,144 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsChecksummedAddress(t *testing.T) {
+	env := map[string]any{
+		"addresses": []any{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "0xde709f2102306220921060314715629080e2fb77"},
+		"mixed":     []any{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"},
+		"empty":     []any{},
+	}
+
+	tests := []struct {
+		name           string
+		exp            string
+		want           bool
+		wantRuntimeErr bool
+	}{
+		{
+			name: "mixed case",
+			exp:  `isChecksummedAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", 42)`,
+			want: true,
+		},
+		{
+			name: "all uppercase checksum",
+			exp:  `isChecksummedAddress("0x52908400098527886E0F7030069857D2E4169EE7", 42)`,
+			want: true,
+		},
+		{
+			name: "all lowercase checksum",
+			exp:  `isChecksummedAddress("0xde709f2102306220921060314715629080e2fb77", 42)`,
+			want: true,
+		},
+		{
+			name: "wrong casing",
+			exp:  `isChecksummedAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", 42)`,
+			want: false,
+		},
+		{
+			name: "one letter flipped",
+			exp:  `isChecksummedAddress("0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", 42)`,
+			want: false,
+		},
+		{
+			name: "uppercase prefix",
+			exp:  `isChecksummedAddress("0X5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", 42)`,
+			want: false,
+		},
+		{
+			name: "custom length",
+			exp:  `isChecksummedAddress("0x1234567890ABcdef1234567890aBcDEF12345678AbCd", 46)`,
+			want: true,
+		},
+		{
+			name: "custom length longer than the hash",
+			exp:  `isChecksummedAddress("0xC6d9D2CD449A754C494264E1809c50e34D64562B0000000000000000000000AB", 66)`,
+			want: true,
+		},
+		{
+			name: "custom length with wrong casing",
+			exp:  `isChecksummedAddress("0x1234567890abcdef1234567890abcdef12345678abcd", 46)`,
+			want: false,
+		},
+		{
+			name: "length mismatch",
+			exp:  `isChecksummedAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", 46)`,
+			want: false,
+		},
+		{
+			name: "list",
+			exp:  `isChecksummedAddress(addresses, 42)`,
+			want: true,
+		},
+		{
+			name: "list with an unchecksummed address",
+			exp:  `isChecksummedAddress(mixed, 42)`,
+			want: false,
+		},
+		{
+			name: "empty list",
+			exp:  `isChecksummedAddress(empty, 42)`,
+			want: true,
+		},
+		{
+			name:           "odd length",
+			exp:            `isChecksummedAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", 41)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "prefix without digits",
+			exp:            `isChecksummedAddress("0x", 2)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "prefix without digits in a list",
+			exp:            `isChecksummedAddress(["0x"], 2)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "zero length",
+			exp:            `isChecksummedAddress("0x", 0)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "negative length",
+			exp:            `isChecksummedAddress("0x", -42)`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), IsChecksummedAddress())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
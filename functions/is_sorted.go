@@ -42,6 +42,11 @@ import (
 //	isSorted(["a", "b", "c"])
 //	isSorted([1.0, 2.0, 3.0])
 //	isSorted(myCustomType) // myCustomType must implement sort.Interface
+//
+// Whether an unsupported input fails when the expression is compiled or when it is run depends on its static type.
+// A value whose type is known to match none of the signatures above, such as a []bool from the environment, is a
+// compile error. Lists built in the expression, like [true, false], and environment values typed []any are only
+// checked when run, so a list of unsupported elements is a runtime error naming the element type.
 func IsSorted() expr.Option {
 	return expr.Function("isSorted", func(params ...any) (any, error) {
 		if len(params) != 1 {
@@ -57,32 +62,82 @@ func IsSorted() expr.Option {
 	)
 }
 
+// IsSortedDesc provides the isSortedDesc function as an Expr function. It is the counterpart of isSorted, verifying
+// that the provided type is sorted descending, and supports the same types.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.IsSortedDesc())
+//
+// Expression:
+//
+//	isSortedDesc([3, 2, 1])
+//	isSortedDesc(["c", "b", "a"])
+//	isSortedDesc([3.0, 2.0, 1.0])
+//	isSortedDesc(myCustomType) // myCustomType must implement sort.Interface
+func IsSortedDesc() expr.Option {
+	return expr.Function("isSortedDesc", func(params ...any) (any, error) {
+		if len(params) != 1 {
+			return false, fmt.Errorf("expected one parameter, got %d", len(params))
+		}
+		return isSortedDesc(params[0])
+	},
+		new(func(sort.Interface) (bool, error)),
+		new(func([]any) (bool, error)),
+		new(func([]int) (bool, error)),
+		new(func([]float64) (bool, error)),
+		new(func([]string) (bool, error)),
+	)
+}
+
 // isSorted attempts to determine if v is sortable, first by determine if it satisfies the sort.Interface interface,
 // then by checking if it is a slice of a sortable type. If the type is a slice of type []any pass it to the
 // isSliceSorted method which builds a new slice of the correct type and validates that it is sorted.
 func isSorted(v any) (any, error) {
+	return checkSorted(v, false)
+}
+
+// isSortedDesc is isSorted for descending order.
+func isSortedDesc(v any) (any, error) {
+	return checkSorted(v, true)
+}
+
+// checkSorted reports whether v is sorted ascending, or descending when desc is true.
+func checkSorted(v any, desc bool) (any, error) {
 	if v == nil {
 		return false, nil
 	}
 
 	switch t := v.(type) {
 	case sort.Interface:
+		if desc {
+			return sort.IsSorted(sort.Reverse(t)), nil
+		}
 		return sort.IsSorted(t), nil
 
 	// There are cases where Expr is passing around an []any instead of a []int, []float64, or []string.
 	// This logic will attempt to do its own sorting to determine if the slice is sorted.
 	case []any:
-		return isSliceSorted(t)
+		return isSliceSorted(t, desc)
 	case []int:
-		return slices.IsSorted(t), nil
+		return isTypedSliceSorted(t, desc), nil
 	case []float64:
-		return slices.IsSorted(t), nil
+		return isTypedSliceSorted(t, desc), nil
 	case []string:
-		return slices.IsSorted(t), nil
+		return isTypedSliceSorted(t, desc), nil
 	}
 	return false, fmt.Errorf("type %s is not sortable", reflect.TypeOf(v))
 }
 
+// isTypedSliceSorted reports whether s is sorted ascending, or descending when desc is true.
+func isTypedSliceSorted[E cmp.Ordered](s []E, desc bool) bool {
+	if desc {
+		return slices.IsSortedFunc(s, func(a, b E) int { return cmp.Compare(b, a) })
+	}
+	return slices.IsSorted(s)
+}
+
 func convertTo[E cmp.Ordered](x any) (E, error) {
 	var r E
 	v, ok := x.(E)
@@ -92,7 +147,7 @@ func convertTo[E cmp.Ordered](x any) (E, error) {
 	return v, nil
 }
 
-func less[E cmp.Ordered](vv []any) (bool, error) {
+func less[E cmp.Ordered](vv []any, desc bool) (bool, error) {
 	for i := len(vv) - 1; i > 0; i-- {
 		l, err := convertTo[E](vv[i-1])
 		if err != nil {
@@ -102,6 +157,9 @@ func less[E cmp.Ordered](vv []any) (bool, error) {
 		if err != nil {
 			return false, err
 		}
+		if desc {
+			l, h = h, l
+		}
 		if cmp.Less(h, l) {
 			return false, nil
 		}
@@ -109,20 +167,24 @@ func less[E cmp.Ordered](vv []any) (bool, error) {
 	return true, nil
 }
 
-// isSliceSorted attempts to determine if v is a slice of a sortable type.
+// isSliceSorted attempts to determine if v is a slice of a sortable type, in ascending order or in descending order
+// when desc is true.
 // Instead of building a slice it just walks the slice and validates that it is sorted. The first unsorted element
 // causes the function to return false.
 // Expr only supports int, float, and string types.
-func isSliceSorted(vv []any) (bool, error) {
+func isSliceSorted(vv []any, desc bool) (bool, error) {
+	if len(vv) == 0 {
+		return true, nil
+	}
 	// We have to peek the first element to determine the type of the slice.
 	switch t := vv[0].(type) {
 	case int:
-		return less[int](vv)
+		return less[int](vv, desc)
 	case float64:
-		return less[float64](vv)
+		return less[float64](vv, desc)
 	case string:
-		return less[string](vv)
+		return less[string](vv, desc)
 	default:
-		return false, fmt.Errorf("unsupported type %T", t)
+		return false, fmt.Errorf("unsupported element type %T, expected int, float64, or string", t)
 	}
 }
@@ -0,0 +1,303 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_isSortedDesc(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		sorted, err := isSortedDesc(nil)
+		require.NoError(t, err)
+		assert.False(t, sorted.(bool))
+	})
+	t.Run("sort.Interface - not sorted", func(t *testing.T) {
+		people := []Person{
+			{"Michael", 17},
+			{"Jenny", 26},
+			{"Bob", 31},
+			{"John", 42},
+		}
+		sorted, err := isSortedDesc(ByAge(people))
+		require.NoError(t, err)
+		assert.False(t, sorted.(bool))
+	})
+	t.Run("sort.Interface - sorted", func(t *testing.T) {
+		people := []Person{
+			{"John", 42},
+			{"Bob", 31},
+			{"Jenny", 26},
+			{"Michael", 17},
+		}
+		sorted, err := isSortedDesc(ByAge(people))
+		require.NoError(t, err)
+		assert.True(t, sorted.(bool))
+	})
+	t.Run("any - int slice - not sorted", func(t *testing.T) {
+		sorted, err := isSortedDesc([]any{1, 2, 3, 4, 5})
+		require.NoError(t, err)
+		assert.False(t, sorted.(bool))
+	})
+	t.Run("any - int slice - sorted", func(t *testing.T) {
+		sorted, err := isSortedDesc([]any{5, 4, 3, 2, 1})
+		require.NoError(t, err)
+		assert.True(t, sorted.(bool))
+	})
+	t.Run("any - int slice - sorted with duplicates", func(t *testing.T) {
+		sorted, err := isSortedDesc([]any{5, 5, 3, 3, 1})
+		require.NoError(t, err)
+		assert.True(t, sorted.(bool))
+	})
+	t.Run("any - mis-typed int slice", func(t *testing.T) {
+		sorted, err := isSortedDesc([]any{5, 4, 3, "2", 1})
+		require.Error(t, err)
+		assert.False(t, sorted.(bool))
+	})
+	t.Run("any - float slice - not sorted", func(t *testing.T) {
+		sorted, err := isSortedDesc([]any{1.0, 2.0, 3.0, 4.0, 5.0})
+		require.NoError(t, err)
+		assert.False(t, sorted.(bool))
+	})
+	t.Run("any - float slice - sorted", func(t *testing.T) {
+		sorted, err := isSortedDesc([]any{5.0, 4.0, 3.0, 2.0, 1.0})
+		require.NoError(t, err)
+		assert.True(t, sorted.(bool))
+	})
+	t.Run("any - mis-typed float slice", func(t *testing.T) {
+		sorted, err := isSortedDesc([]any{5.0, 4.0, 3.0, "2.0", 1.0})
+		require.Error(t, err)
+		assert.False(t, sorted.(bool))
+	})
+	t.Run("any - string slice - not sorted", func(t *testing.T) {
+		sorted, err := isSortedDesc([]any{"1", "2", "3", "4", "5"})
+		require.NoError(t, err)
+		assert.False(t, sorted.(bool))
+	})
+	t.Run("any - string slice - sorted", func(t *testing.T) {
+		sorted, err := isSortedDesc([]any{"5", "4", "3", "2", "1"})
+		require.NoError(t, err)
+		assert.True(t, sorted.(bool))
+	})
+	t.Run("any - mis-typed string slice", func(t *testing.T) {
+		sorted, err := isSortedDesc([]any{"5", "4", "3", 2, "1"})
+		require.Error(t, err)
+		assert.False(t, sorted.(bool))
+	})
+	t.Run("any - unsupported type", func(t *testing.T) {
+		sorted, err := isSortedDesc([]any{Person{"Bob", 31}})
+		require.Error(t, err)
+		assert.False(t, sorted.(bool))
+	})
+	t.Run("any - empty slice", func(t *testing.T) {
+		sorted, err := isSortedDesc([]any{})
+		require.NoError(t, err)
+		assert.True(t, sorted.(bool))
+	})
+	t.Run("int slice - not sorted", func(t *testing.T) {
+		sorted, err := isSortedDesc([]int{1, 2, 3, 4, 5})
+		require.NoError(t, err)
+		assert.False(t, sorted.(bool))
+	})
+	t.Run("int slice - sorted", func(t *testing.T) {
+		sorted, err := isSortedDesc([]int{5, 4, 3, 2, 1})
+		require.NoError(t, err)
+		assert.True(t, sorted.(bool))
+	})
+	t.Run("float slice - not sorted", func(t *testing.T) {
+		sorted, err := isSortedDesc([]float64{1.0, 2.0, 3.0, 4.0, 5.0})
+		require.NoError(t, err)
+		assert.False(t, sorted.(bool))
+	})
+	t.Run("float slice - sorted", func(t *testing.T) {
+		sorted, err := isSortedDesc([]float64{5.0, 4.0, 3.0, 2.0, 1.0})
+		require.NoError(t, err)
+		assert.True(t, sorted.(bool))
+	})
+	t.Run("string slice - not sorted", func(t *testing.T) {
+		sorted, err := isSortedDesc([]string{"1", "2", "3", "4", "5"})
+		require.NoError(t, err)
+		assert.False(t, sorted.(bool))
+	})
+	t.Run("string slice - sorted", func(t *testing.T) {
+		sorted, err := isSortedDesc([]string{"5", "4", "3", "2", "1"})
+		require.NoError(t, err)
+		assert.True(t, sorted.(bool))
+	})
+	t.Run("unsupported type", func(t *testing.T) {
+		sorted, err := isSortedDesc(Person{"Bob", 31})
+		require.Error(t, err)
+		assert.False(t, sorted.(bool))
+	})
+}
+
+func TestIsSortedDesc(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           bool
+		wantCompileErr bool
+		wantRuntimeErr bool
+	}{
+		{
+			name: "nil",
+			exp:  `isSortedDesc(nil)`,
+			want: false,
+		},
+		{
+			name: "sort.Interface - not sorted",
+			exp:  `isSortedDesc(people_unsorted)`,
+		},
+		{
+			name: "sort.Interface - sorted",
+			exp:  `isSortedDesc(people_sorted)`,
+			want: true,
+		},
+		{
+			name: "int slice - not sorted",
+			exp:  `isSortedDesc(ints_unsorted)`,
+		},
+		{
+			name: "int slice - sorted",
+			exp:  `isSortedDesc(ints_sorted)`,
+			want: true,
+		},
+		{
+			name: "float slice - not sorted",
+			exp:  `isSortedDesc(floats_unsorted)`,
+		},
+		{
+			name: "float slice - sorted",
+			exp:  `isSortedDesc(floats_sorted)`,
+			want: true,
+		},
+		{
+			name: "string slice - not sorted",
+			exp:  `isSortedDesc(strings_unsorted)`,
+		},
+		{
+			name: "string slice - sorted",
+			exp:  `isSortedDesc(strings_sorted)`,
+			want: true,
+		},
+		{
+			name: "any - int slice - not sorted",
+			exp:  `isSortedDesc(any_unsorted)`,
+		},
+		{
+			name: "any - int slice - sorted",
+			exp:  `isSortedDesc(any_sorted)`,
+			want: true,
+		},
+		{
+			name: "literal - sorted",
+			exp:  `isSortedDesc([3, 2, 1])`,
+			want: true,
+		},
+		{
+			name:           "any - mis-typed int slice",
+			exp:            `isSortedDesc(any_mixed_slice)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "unsupported type",
+			exp:            `isSortedDesc(v)`,
+			wantCompileErr: true,
+		},
+		{
+			name:           "bool slice",
+			exp:            `isSortedDesc(bools)`,
+			wantCompileErr: true,
+		},
+		{
+			name:           "any - bool slice",
+			exp:            `isSortedDesc(any_bools)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name: "any - empty slice",
+			exp:  `isSortedDesc(any_empty)`,
+			want: true,
+		},
+		{
+			name:           "no argument",
+			exp:            `isSortedDesc()`,
+			wantCompileErr: true,
+		},
+		{
+			name:           "too many arguments",
+			exp:            `isSortedDesc(ints_sorted, ints_sorted)`,
+			wantCompileErr: true,
+		},
+	}
+
+	people := []Person{
+		{"John", 42},
+		{"Bob", 31},
+		{"Jenny", 26},
+		{"Michael", 17},
+	}
+	input := map[string]any{
+		"people_sorted": ByAge(people),
+		"people_unsorted": func() ByAge {
+			ii := make([]Person, len(people))
+			copy(ii, people)
+			ii[0], ii[1] = ii[1], ii[0]
+			return ii
+		}(),
+		"ints_sorted":      []int{5, 4, 3, 2, 1},
+		"ints_unsorted":    []int{1, 2, 3, 4, 5},
+		"floats_sorted":    []float64{5.0, 4.0, 3.0, 2.0, 1.0},
+		"floats_unsorted":  []float64{1.0, 2.0, 3.0, 4.0, 5.0},
+		"strings_sorted":   []string{"5", "4", "3", "2", "1"},
+		"strings_unsorted": []string{"1", "2", "3", "4", "5"},
+		"any_unsorted":     []any{1, 2, 3, 4, 5},
+		"any_sorted":       []any{5, 4, 3, 2, 1},
+		"any_mixed_slice":  []any{5, 4, 3, "2", 1},
+		"any_bools":        []any{true, false},
+		"any_empty":        []any{},
+		"bools":            []bool{true, false},
+		"v":                true,
+	}
+	opts := []expr.Option{
+		expr.Env(input),
+		expr.AsBool(),
+		expr.DisableAllBuiltins(),
+		IsSortedDesc(),
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, opts...)
+			if tc.wantCompileErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, input)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, tc.want, got)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
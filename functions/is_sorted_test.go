@@ -118,6 +118,16 @@ func Test_isSorted(t *testing.T) {
 		require.Error(t, err)
 		assert.False(t, sorted.(bool))
 	})
+	t.Run("any - bool slice", func(t *testing.T) {
+		sorted, err := isSorted([]any{true, false})
+		require.ErrorContains(t, err, "unsupported element type bool")
+		assert.False(t, sorted.(bool))
+	})
+	t.Run("any - empty slice", func(t *testing.T) {
+		sorted, err := isSorted([]any{})
+		require.NoError(t, err)
+		assert.True(t, sorted.(bool))
+	})
 	t.Run("int slice - not sorted", func(t *testing.T) {
 		sorted, err := isSorted([]int{5, 4, 3, 2, 1})
 		require.NoError(t, err)
@@ -223,6 +233,28 @@ func TestIsSorted(t *testing.T) {
 			exp:            `isSorted(v)`,
 			wantCompileErr: true,
 		},
+		{
+			// A typed []bool matches none of the signatures, so it is rejected before the expression runs.
+			name:           "bool slice",
+			exp:            `isSorted(bools)`,
+			wantCompileErr: true,
+		},
+		{
+			// The same values in an []any can only be checked once the elements are seen.
+			name:           "any - bool slice",
+			exp:            `isSorted(any_bools)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "bool slice literal",
+			exp:            `isSorted([true, false])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name: "any - empty slice",
+			exp:  `isSorted(any_empty)`,
+			want: true,
+		},
 		{
 			name:           "no argument",
 			exp:            `isSorted()`,
@@ -258,6 +290,9 @@ func TestIsSorted(t *testing.T) {
 		"any_unsorted":     []any{5, 4, 3, 2, 1},
 		"any_sorted":       []any{1, 2, 3, 4, 5},
 		"any_mixed_slice":  []any{1, 2, 3, "4", 5},
+		"any_bools":        []any{true, false},
+		"any_empty":        []any{},
+		"bools":            []bool{true, false},
 		"v":                true,
 	}
 	opts := []expr.Option{
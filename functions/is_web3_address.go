@@ -0,0 +1,92 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// IsWeb3Address provides the isWeb3Address function as an Expr function. It reports whether a string is a
+// well-formed Ethereum address: "0x" followed by 40 hexadecimal digits, in any case. Unlike an EIP-55 checksum check,
+// the casing of the digits is not validated, so all-lowercase and all-uppercase addresses are accepted. Given a list,
+// it reports whether every element is an address, so an empty list is vacuously true, as it is for all.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.IsWeb3Address())
+//
+// Expression:
+//
+//	isWeb3Address("0x52908400098527886e0f7030069857d2e4169ee7") // true
+//	isWeb3Address("0x5290840009852788")                         // false
+func IsWeb3Address() expr.Option {
+	return expr.Function("isWeb3Address", func(params ...any) (any, error) {
+		if len(params) != 1 {
+			return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+		}
+		return allStrings(params[0], isWeb3Address)
+	},
+		new(func(string) (bool, error)),
+		new(func([]any) (bool, error)),
+		new(func([]string) (bool, error)),
+	)
+}
+
+// isWeb3Address reports whether s is "0x" followed by 40 hexadecimal digits.
+func isWeb3Address(s string) bool {
+	return isHexAddress(s, 42)
+}
+
+// isHexAddress reports whether s is "0x" followed by hexadecimal digits, length characters in total.
+func isHexAddress(s string, length int) bool {
+	if len(s) != length || len(s) < 2 || s[0] != '0' || (s[1] != 'x' && s[1] != 'X') {
+		return false
+	}
+	for _, r := range s[2:] {
+		if !isHexDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isHexDigit(r rune) bool {
+	return ('0' <= r && r <= '9') || ('a' <= r && r <= 'f') || ('A' <= r && r <= 'F')
+}
+
+// allStrings applies pred to v if it is a string, or to every element if it is a list, and reports whether every
+// result was true. Like the all builtin, it is vacuously true for an empty list.
+func allStrings(v any, pred func(string) bool) (bool, error) {
+	if s, ok := v.(string); ok {
+		return pred(s), nil
+	}
+	list, err := toList(v)
+	if err != nil {
+		return false, err
+	}
+	for i, e := range list {
+		s, ok := e.(string)
+		if !ok {
+			return false, fmt.Errorf("element %d: expected string, got %T", i, e)
+		}
+		if !pred(s) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
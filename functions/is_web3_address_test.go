@@ -0,0 +1,111 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsWeb3Address(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           bool
+		wantRuntimeErr bool
+	}{
+		{
+			name: "lowercase",
+			exp:  `isWeb3Address("0x52908400098527886e0f7030069857d2e4169ee7")`,
+			want: true,
+		},
+		{
+			name: "uppercase",
+			exp:  `isWeb3Address("0x52908400098527886E0F7030069857D2E4169EE7")`,
+			want: true,
+		},
+		{
+			name: "checksummed",
+			exp:  `isWeb3Address("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")`,
+			want: true,
+		},
+		{
+			name: "wrongly checksummed is still an address",
+			exp:  `isWeb3Address("0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")`,
+			want: true,
+		},
+		{
+			name: "too short",
+			exp:  `isWeb3Address("0x5290840009852788")`,
+			want: false,
+		},
+		{
+			name: "missing prefix",
+			exp:  `isWeb3Address("52908400098527886e0f7030069857d2e4169ee7ab")`,
+			want: false,
+		},
+		{
+			name: "non-hex digit",
+			exp:  `isWeb3Address("0x52908400098527886e0f7030069857d2e4169eeg")`,
+			want: false,
+		},
+		{
+			name: "list of addresses",
+			exp:  `isWeb3Address(["0x52908400098527886e0f7030069857d2e4169ee7", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"])`,
+			want: true,
+		},
+		{
+			name: "list with a malformed address",
+			exp:  `isWeb3Address(["0x52908400098527886e0f7030069857d2e4169ee7", "0x123"])`,
+			want: false,
+		},
+		{
+			name: "typed list from the environment",
+			exp:  `isWeb3Address(wallets)`,
+			want: true,
+		},
+		{
+			name: "empty list is vacuously true",
+			exp:  `isWeb3Address([])`,
+			want: true,
+		},
+		{
+			name:           "non-string element",
+			exp:            `isWeb3Address([1])`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{
+		"wallets": []string{"0xde709f2102306220921060314715629080e2fb77", "0x27b1fdb04752bbc536007a920d24acb045561c26"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), IsWeb3Address())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/expr-lang/expr"
+)
+
+// JoinNonEmpty provides the joinNonEmpty function as an Expr function. It joins the elements of a list with a
+// separator, skipping nil and empty string elements so the result has no doubled separators. Elements that are not
+// strings are rendered with fmt.Sprint.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.JoinNonEmpty())
+//
+// Expression:
+//
+//	joinNonEmpty(["a", nil, "", "b", 1], ", ") // "a, b, 1"
+func JoinNonEmpty() expr.Option {
+	return expr.Function("joinNonEmpty", func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		sep, err := arg[string](params, 1)
+		if err != nil {
+			return nil, err
+		}
+		return joinNonEmpty(params[0], sep)
+	},
+		new(func([]any, string) (string, error)),
+		new(func([]string, string) (string, error)),
+	)
+}
+
+// joinNonEmpty joins the non-nil, non-empty elements of v with sep.
+func joinNonEmpty(v any, sep string) (string, error) {
+	var parts []string
+	switch t := v.(type) {
+	case []string:
+		for _, s := range t {
+			if s != "" {
+				parts = append(parts, s)
+			}
+		}
+	case []any:
+		for _, e := range t {
+			if e == nil {
+				continue
+			}
+			if s := fmt.Sprint(e); s != "" {
+				parts = append(parts, s)
+			}
+		}
+	default:
+		return "", fmt.Errorf("type %s is not a list", reflect.TypeOf(v))
+	}
+	return strings.Join(parts, sep), nil
+}
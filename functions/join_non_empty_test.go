@@ -0,0 +1,64 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoinNonEmpty(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want string
+	}{
+		{
+			name: "nils and empties interspersed",
+			exp:  `joinNonEmpty([nil, "a", "", nil, "b", "", "c", nil], ", ")`,
+			want: "a, b, c",
+		},
+		{
+			name: "non-string elements",
+			exp:  `joinNonEmpty(["port", 8080, true, 1.5], ":")`,
+			want: "port:8080:true:1.5",
+		},
+		{
+			name: "string slice",
+			exp:  `joinNonEmpty(parts, "/")`,
+			want: "usr/local/bin",
+		},
+		{
+			name: "all empty",
+			exp:  `joinNonEmpty([nil, ""], ",")`,
+			want: "",
+		},
+	}
+
+	env := map[string]any{"parts": []string{"", "usr", "local", "", "bin"}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), JoinNonEmpty())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
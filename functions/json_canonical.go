@@ -0,0 +1,79 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// JSONCanonical provides the jsonCanonical function as an Expr function. It encodes a value as JSON with the keys of
+// every object sorted and no whitespace between tokens, so values that are equal always encode to the same bytes,
+// whatever order their keys were written in. This makes the output suitable for hashing or signing. Characters such
+// as < and & are written as they are rather than escaped.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.JSONCanonical())
+//
+// Expression:
+//
+//	jsonCanonical({"b": [1, 2], "a": {"d": true, "c": nil}}) // `{"a":{"c":null,"d":true},"b":[1,2]}`
+func JSONCanonical() expr.Option {
+	return expr.Function("jsonCanonical", func(params ...any) (any, error) {
+		if len(params) != 1 {
+			return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+		}
+		return jsonCanonical(params[0])
+	},
+		new(func(any) (string, error)),
+	)
+}
+
+// jsonCanonical returns the canonical JSON encoding of v. The value is encoded and decoded once first, so that structs
+// and values with their own encoding are reduced to maps, whose keys encoding/json sorts.
+func jsonCanonical(v any) (string, error) {
+	b, err := encodeJSON(v)
+	if err != nil {
+		return "", err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	// Keep numbers as written, rather than rounding them through float64.
+	dec.UseNumber()
+	var decoded any
+	if err := dec.Decode(&decoded); err != nil {
+		return "", err
+	}
+	b, err = encodeJSON(decoded)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// encodeJSON encodes v as JSON without escaping HTML characters.
+func encodeJSON(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONCanonical(t *testing.T) {
+	env := map[string]any{
+		"payload": map[string]any{
+			"to":     "0xabc",
+			"amount": 10,
+			"meta":   map[string]any{"z": 1, "a": []any{3, 2, 1}},
+		},
+		"struct": struct {
+			Zeta  string `json:"zeta"`
+			Alpha int    `json:"alpha"`
+		}{"z", 1},
+		"time": time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name    string
+		exp     string
+		want    any
+		wantErr bool
+	}{
+		{
+			name: "keys are sorted recursively",
+			exp:  `jsonCanonical(payload)`,
+			want: `{"amount":10,"meta":{"a":[3,2,1],"z":1},"to":"0xabc"}`,
+		},
+		{
+			name: "differently ordered keys encode identically",
+			exp:  `jsonCanonical({"b": 1, "a": {"y": 2, "x": 3}}) == jsonCanonical({"a": {"x": 3, "y": 2}, "b": 1})`,
+			want: true,
+		},
+		{
+			name: "equal to a literal written in another order",
+			exp:  `jsonCanonical(payload) == jsonCanonical({"meta": {"a": [3, 2, 1], "z": 1}, "amount": 10, "to": "0xabc"})`,
+			want: true,
+		},
+		{
+			name: "struct fields are sorted",
+			exp:  `jsonCanonical(struct)`,
+			want: `{"alpha":1,"zeta":"z"}`,
+		},
+		{
+			name: "scalars",
+			exp:  `[jsonCanonical(nil), jsonCanonical(1.5), jsonCanonical("a<b&c"), jsonCanonical(time)]`,
+			want: []any{`null`, `1.5`, `"a<b&c"`, `"2024-02-26T00:00:00Z"`},
+		},
+		{
+			name: "large integers are kept exact",
+			exp:  `jsonCanonical([9007199254740993])`,
+			want: `[9007199254740993]`,
+		},
+		{
+			name:    "NaN cannot be encoded",
+			exp:     `jsonCanonical([1, 0.0 / 0.0])`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), JSONCanonical())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
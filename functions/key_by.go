@@ -0,0 +1,69 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// KeyBy provides the keyBy function as an Expr function. It indexes a list of maps by the value of one of their
+// fields, returning a map from that value to the element. The field must be present and hold a string in every
+// element. When two elements share a key the last one wins.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.KeyBy())
+//
+// Expression:
+//
+//	keyBy([{"id": "a", "n": 1}, {"id": "b", "n": 2}], "id") // {"a": {"id": "a", "n": 1}, "b": {"id": "b", "n": 2}}
+//	keyBy(users, "name")["alice"].role == "admin"
+func KeyBy() expr.Option {
+	return expr.Function("keyBy", func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		list, err := toList(params[0])
+		if err != nil {
+			return nil, err
+		}
+		key, err := arg[string](params, 1)
+		if err != nil {
+			return nil, err
+		}
+		out := make(map[string]any, len(list))
+		for i, v := range list {
+			m, ok := v.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("element %d: expected a map, got %T", i, v)
+			}
+			kv, ok := m[key]
+			if !ok {
+				return nil, fmt.Errorf("element %d: missing key %q", i, key)
+			}
+			k, ok := kv.(string)
+			if !ok {
+				return nil, fmt.Errorf("element %d: key %q: expected a string, got %T", i, key, kv)
+			}
+			out[k] = m
+		}
+		return out, nil
+	},
+		new(func([]any, string) (map[string]any, error)),
+	)
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyBy(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "unique keys",
+			exp:  `keyBy([{"id": "a", "n": 1}, {"id": "b", "n": 2}], "id")`,
+			want: map[string]any{
+				"a": map[string]any{"id": "a", "n": 1},
+				"b": map[string]any{"id": "b", "n": 2},
+			},
+		},
+		{
+			name: "duplicate keys last wins",
+			exp:  `keyBy([{"id": "a", "n": 1}, {"id": "a", "n": 2}], "id")`,
+			want: map[string]any{
+				"a": map[string]any{"id": "a", "n": 2},
+			},
+		},
+		{
+			name: "lookup from the environment",
+			exp:  `keyBy(users, "name")["alice"].role`,
+			want: "admin",
+		},
+		{
+			name: "empty list",
+			exp:  `keyBy([], "id")`,
+			want: map[string]any{},
+		},
+		{
+			name:           "missing key",
+			exp:            `keyBy([{"id": "a"}, {"name": "b"}], "id")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "non-string key value",
+			exp:            `keyBy([{"id": 1}], "id")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "non-map element",
+			exp:            `keyBy(["a"], "id")`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{
+		"users": []any{
+			map[string]any{"name": "alice", "role": "admin"},
+			map[string]any{"name": "bob", "role": "viewer"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), KeyBy())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
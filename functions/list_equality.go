@@ -0,0 +1,80 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+)
+
+// listEqualName is the name the list comparison function is registered under. It is not a valid identifier, so it
+// can only be called by listEqualityPatch.
+const listEqualName = "list.equal"
+
+// ListEquality makes == and != compare lists element by element. Expr compares lists with reflect.DeepEqual, so lists
+// of different Go types are never equal, even when their elements are: a []int from the environment does not equal
+// the []any returned by sort or a list literal. This option patches comparisons where either side is known to be a
+// list to compare them the way Expr compares their elements, so numbers compare by value regardless of their type.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.ListEquality())
+//
+// Expression:
+//
+//	items == sort(items)     // true, when items is the []int{1, 2, 3}
+//	[1, 2, 3] == [1.0, 2, 3] // true
+func ListEquality() expr.Option {
+	return options(
+		expr.Function(listEqualName, func(params ...any) (any, error) {
+			return equal(params[0], params[1]), nil
+		},
+			new(func(any, any) bool),
+		),
+		expr.Patch(listEqualityPatch{}),
+	)
+}
+
+// listEqualityPatch rewrites a == b as list.equal(a, b), and a != b as !list.equal(a, b), when a or b is a list.
+type listEqualityPatch struct{}
+
+func (listEqualityPatch) Visit(node *ast.Node) {
+	n, ok := (*node).(*ast.BinaryNode)
+	if !ok || n.Operator != "==" && n.Operator != "!=" {
+		return
+	}
+	if !isListType(n.Left) && !isListType(n.Right) {
+		return
+	}
+
+	callee := &ast.IdentifierNode{Value: listEqualName}
+	callee.SetLocation(n.Location())
+	var patched ast.Node = &ast.CallNode{
+		Callee:    callee,
+		Arguments: []ast.Node{n.Left, n.Right},
+	}
+	if n.Operator == "!=" {
+		patched.SetLocation(n.Location())
+		patched = &ast.UnaryNode{Operator: "!", Node: patched}
+	}
+	ast.Patch(node, patched)
+}
+
+// isListType reports whether node is known to be a list.
+func isListType(node ast.Node) bool {
+	t := node.Type()
+	return t != nil && isListKind(t.Kind())
+}
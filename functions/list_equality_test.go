@@ -0,0 +1,61 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListEquality(t *testing.T) {
+	env := map[string]any{
+		"items":  []int{1, 2, 3},
+		"names":  []string{"b", "a"},
+		"object": map[string]any{"items": []int{3, 1, 2}},
+	}
+
+	tests := []struct {
+		name string
+		exp  string
+		want bool
+	}{
+		{name: "typed list equals sorted copy", exp: `items == sort(items)`, want: true},
+		{name: "values only known at runtime are unaffected", exp: `object.items == object.items`, want: true},
+		{name: "unsorted list does not equal sorted copy", exp: `object.items == sort(object.items)`, want: false},
+		{name: "not equal", exp: `object.items != sort(object.items)`, want: true},
+		{name: "not equal when equal", exp: `items != sort(items)`, want: false},
+		{name: "typed list equals literal", exp: `items == [1, 2, 3]`, want: true},
+		{name: "numbers compare by value", exp: `[1, 2, 3] == [1.0, 2, 3]`, want: true},
+		{name: "different lengths", exp: `items == [1, 2]`, want: false},
+		{name: "strings", exp: `sort(names) == ["a", "b"]`, want: true},
+		{name: "nested lists", exp: `[[1], [2, 3]] == [[1.0], [2, 3]]`, want: true},
+		{name: "list and nil", exp: `items == nil`, want: false},
+		{name: "scalars are unaffected", exp: `1 == 1.0 && "a" != "b"`, want: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), ListEquality())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
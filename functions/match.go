@@ -0,0 +1,79 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+)
+
+// Match provides the allMatch, anyMatch, and noneMatch functions as Expr functions. They report whether every, at
+// least one, or no element of a list satisfies a predicate. For an empty list the answers are vacuously true for
+// allMatch, false for anyMatch, and true for noneMatch. Evaluation stops as soon as the answer is known.
+//
+// Expr reserves closure literals for its builtins, so the predicate is given as a string literal holding the closure
+// body, where # refers to the current element. The calls are compiled into the all, any, and none builtins, so the
+// predicate can reference other variables.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Match())
+//
+// Expression:
+//
+//	allMatch([1, 2, 3], "# > 0")  // true
+//	anyMatch([1, 2, 3], "# > 2")  // true
+//	noneMatch([1, 2, 3], "# > 2") // false
+func Match() expr.Option {
+	return options(
+		matchFunction("allMatch"),
+		matchFunction("anyMatch"),
+		matchFunction("noneMatch"),
+		expr.Patch(matchPatch{}),
+	)
+}
+
+// matchBuiltins maps each match function to the builtin its calls are compiled into.
+var matchBuiltins = map[string]string{
+	"allMatch":  "all",
+	"anyMatch":  "any",
+	"noneMatch": "none",
+}
+
+// matchFunction registers the match function name, which is only called when matchPatch could not rewrite the call.
+func matchFunction(name string) expr.Option {
+	return expr.Function(name, func(params ...any) (any, error) {
+		return nil, closureError("predicate", params)
+	},
+		new(func([]any, string) (bool, error)),
+		new(func([]int, string) (bool, error)),
+		new(func([]float64, string) (bool, error)),
+		new(func([]string, string) (bool, error)),
+	)
+}
+
+// matchPatch rewrites allMatch(list, "body"), anyMatch(list, "body"), and noneMatch(list, "body") as all(list, body),
+// any(list, body), and none(list, body).
+type matchPatch struct{}
+
+func (matchPatch) Visit(node *ast.Node) {
+	for name, builtin := range matchBuiltins {
+		if args, closure, ok := closureCall(*node, name, 2); ok {
+			ast.Patch(node, newBuiltin(builtin, args[0], closure))
+			return
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/expr-lang/expr"
+)
+
+// wildcard is the template marker matching any value.
+const wildcard = "*"
+
+// typeMarkers are the template markers matching any value of a kind, by the name that follows the "?".
+var typeMarkers = map[string]func(v any) bool{
+	"string": func(v any) bool {
+		_, ok := v.(string)
+		return ok
+	},
+	"number": func(v any) bool {
+		_, err := toFloat(v)
+		return err == nil
+	},
+	"int": func(v any) bool {
+		if _, err := toInt(v); err == nil {
+			return true
+		}
+		// Numbers decoded from JSON are float64, even when they are whole.
+		f, ok := v.(float64)
+		return ok && f == math.Trunc(f) && !math.IsInf(f, 0)
+	},
+	"bool": func(v any) bool {
+		_, ok := v.(bool)
+		return ok
+	},
+	"list": func(v any) bool {
+		return v != nil && isListKind(reflect.TypeOf(v).Kind())
+	},
+	"map": func(v any) bool {
+		return v != nil && reflect.TypeOf(v).Kind() == reflect.Map
+	},
+	"null": func(v any) bool {
+		return v == nil
+	},
+}
+
+// MatchTemplate provides the matchTemplate function as an Expr function. It reports whether a value has the shape of
+// a template. A map in the template matches a map holding at least its keys, with each value matching the template's,
+// and a list matches a list of the same length whose elements match in order. The string "*" matches any value, and
+// the markers "?string", "?number", "?int", "?bool", "?list", "?map", and "?null" match any value of that kind. Any
+// other value in the template must equal the value it is matched against. An unknown marker is an error.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.MatchTemplate())
+//
+// Expression:
+//
+//	matchTemplate({"name": "web", "replicas": 3}, {"name": "?string", "replicas": "?int"}) // true
+//	matchTemplate({"kind": "Pod", "spec": {}}, {"kind": "Deployment", "spec": "*"})       // false
+//	matchTemplate([1, "a"], ["?number", "*"])                                             // true
+func MatchTemplate() expr.Option {
+	return expr.Function("matchTemplate", func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		if err := validateTemplate(params[1], ""); err != nil {
+			return nil, err
+		}
+		return matchTemplate(params[0], params[1]), nil
+	},
+		new(func(any, any) (bool, error)),
+	)
+}
+
+// validateTemplate returns an error if template holds an unknown marker. path locates template within the top-level
+// template.
+func validateTemplate(template any, path string) error {
+	if s, ok := template.(string); ok {
+		if name, ok := strings.CutPrefix(s, "?"); ok {
+			if _, ok := typeMarkers[name]; !ok {
+				if path == "" {
+					path = "the root"
+				}
+				return fmt.Errorf("unknown template marker %q at %s", s, path)
+			}
+		}
+		return nil
+	}
+
+	rt := reflect.ValueOf(template)
+	switch {
+	case rt.Kind() == reflect.Map:
+		iter := rt.MapRange()
+		for iter.Next() {
+			key := fmt.Sprint(iter.Key().Interface())
+			if path != "" {
+				key = path + "." + key
+			}
+			if err := validateTemplate(iter.Value().Interface(), key); err != nil {
+				return err
+			}
+		}
+	case isListKind(rt.Kind()):
+		for i := 0; i < rt.Len(); i++ {
+			if err := validateTemplate(rt.Index(i).Interface(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// matchTemplate reports whether v matches template, which must be valid.
+func matchTemplate(v, template any) bool {
+	if s, ok := template.(string); ok {
+		if s == wildcard {
+			return true
+		}
+		if name, ok := strings.CutPrefix(s, "?"); ok {
+			return typeMarkers[name](v)
+		}
+	}
+
+	rt := reflect.ValueOf(template)
+	switch {
+	case rt.Kind() == reflect.Map:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Map || rv.Type().Key() != rt.Type().Key() {
+			return false
+		}
+		iter := rt.MapRange()
+		for iter.Next() {
+			value := rv.MapIndex(iter.Key())
+			if !value.IsValid() || !matchTemplate(value.Interface(), iter.Value().Interface()) {
+				return false
+			}
+		}
+		return true
+	case isListKind(rt.Kind()):
+		rv := reflect.ValueOf(v)
+		if !isListKind(rv.Kind()) || rv.Len() != rt.Len() {
+			return false
+		}
+		for i := 0; i < rt.Len(); i++ {
+			if !matchTemplate(rv.Index(i).Interface(), rt.Index(i).Interface()) {
+				return false
+			}
+		}
+		return true
+	}
+	return equal(v, template)
+}
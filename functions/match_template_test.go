@@ -0,0 +1,146 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchTemplate(t *testing.T) {
+	env := map[string]any{
+		"deployment": map[string]any{
+			"kind": "Deployment",
+			"metadata": map[string]any{
+				"name":   "web",
+				"labels": map[string]any{"app": "web", "tier": "frontend"},
+			},
+			"spec": map[string]any{
+				"replicas": 3.0,
+				"paused":   false,
+				"containers": []any{
+					map[string]any{"name": "nginx", "ports": []int{80, 443}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		exp     string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "nested object with wildcard leaves",
+			exp: `matchTemplate(deployment, {
+				"kind": "Deployment",
+				"metadata": {"name": "?string", "labels": "*"},
+				"spec": {
+					"replicas": "?int",
+					"paused": "?bool",
+					"containers": [{"name": "?string", "ports": "?list"}],
+				},
+			})`,
+			want: true,
+		},
+		{
+			name: "extra keys in the value are allowed",
+			exp:  `matchTemplate(deployment, {"kind": "*"})`,
+			want: true,
+		},
+		{
+			name: "missing key",
+			exp:  `matchTemplate(deployment, {"status": "*"})`,
+			want: false,
+		},
+		{
+			name: "literal leaf does not match",
+			exp:  `matchTemplate(deployment, {"kind": "Pod", "metadata": "*"})`,
+			want: false,
+		},
+		{
+			name: "wrong kind of leaf",
+			exp:  `matchTemplate(deployment, {"metadata": {"name": "?number"}})`,
+			want: false,
+		},
+		{
+			name: "map template against a scalar",
+			exp:  `matchTemplate(deployment.kind, {"kind": "*"})`,
+			want: false,
+		},
+		{
+			name: "lists match element by element",
+			exp:  `matchTemplate(deployment.spec.containers[0].ports, ["?int", 443])`,
+			want: true,
+		},
+		{
+			name: "lists of different lengths",
+			exp:  `matchTemplate(deployment.spec.containers[0].ports, ["?int"])`,
+			want: false,
+		},
+		{
+			name: "numbers compare by value",
+			exp:  `matchTemplate({"replicas": 3}, {"replicas": 3.0})`,
+			want: true,
+		},
+		{
+			name: "fractional number is not an int",
+			exp:  `matchTemplate(1.5, "?int") || !matchTemplate(1.5, "?number")`,
+			want: false,
+		},
+		{
+			name: "wildcard matches nil",
+			exp:  `matchTemplate({"a": nil}, {"a": "*"}) && matchTemplate(nil, "?null")`,
+			want: true,
+		},
+		{
+			name: "map marker",
+			exp:  `matchTemplate(deployment.metadata, "?map") && !matchTemplate(deployment.metadata, "?list")`,
+			want: true,
+		},
+		{
+			name:    "unknown marker",
+			exp:     `matchTemplate(deployment, {"spec": {"replicas": "?integer"}})`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), MatchTemplate())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestMatchTemplateUnknownMarkerPath(t *testing.T) {
+	program, err := expr.Compile(`matchTemplate({}, {"spec": {"ports": ["?int", "?port"]}})`, MatchTemplate())
+	require.NoError(t, err)
+
+	_, err = expr.Run(program, nil)
+	require.ErrorContains(t, err, `unknown template marker "?port" at spec.ports[1]`)
+}
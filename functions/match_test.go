@@ -0,0 +1,124 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           bool
+		wantRuntimeErr bool
+	}{
+		{
+			name: "allMatch empty",
+			exp:  `allMatch([], "# > 0")`,
+			want: true,
+		},
+		{
+			name: "allMatch all pass",
+			exp:  `allMatch([1, 2, 3], "# > 0")`,
+			want: true,
+		},
+		{
+			name: "allMatch mixed",
+			exp:  `allMatch([1, -2, 3], "# > 0")`,
+			want: false,
+		},
+		{
+			name: "anyMatch empty",
+			exp:  `anyMatch([], "# > 0")`,
+			want: false,
+		},
+		{
+			name: "anyMatch all pass",
+			exp:  `anyMatch([1, 2, 3], "# > 0")`,
+			want: true,
+		},
+		{
+			name: "anyMatch mixed",
+			exp:  `anyMatch([-1, 2, -3], "# > 0")`,
+			want: true,
+		},
+		{
+			name: "noneMatch empty",
+			exp:  `noneMatch([], "# > 0")`,
+			want: true,
+		},
+		{
+			name: "noneMatch all pass",
+			exp:  `noneMatch([1, 2, 3], "# > 0")`,
+			want: false,
+		},
+		{
+			name: "noneMatch mixed",
+			exp:  `noneMatch([-1, 2, -3], "# > 0")`,
+			want: false,
+		},
+		{
+			name: "noneMatch none pass",
+			exp:  `noneMatch([-1, -2], "# > 0")`,
+			want: true,
+		},
+		{
+			name: "typed list from the environment",
+			exp:  `allMatch(hosts, "# endsWith '.internal'")`,
+			want: true,
+		},
+		{
+			name: "stops at the first decisive element",
+			exp:  `anyMatch([1, "two"], "# == 1")`,
+			want: true,
+		},
+		{
+			name: "predicate references a variable",
+			exp:  `anyMatch(hosts, "# == primary")`,
+			want: true,
+		},
+		{
+			name:           "predicate is not a literal",
+			exp:            `allMatch([1], trim("# > 0"))`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "invalid predicate",
+			exp:            `allMatch([1], "# >")`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{"hosts": []string{"db.internal", "cache.internal"}, "primary": "db.internal"}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Match())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// MergeSorted provides the mergeSorted function as an Expr function. It merges two sorted lists into a new sorted
+// list in linear time. Both lists must hold only numbers or only strings, and a mix of the two is an error. Numbers
+// of different types, such as a []int and a []float64, merge by value. When
+// elements are equal, those from the first list come first. As with sortedInsertIndex, the inputs are not checked for
+// order.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.MergeSorted())
+//
+// Expression:
+//
+//	mergeSorted([1, 4, 7], [2, 3, 8]) // [1, 2, 3, 4, 7, 8]
+func MergeSorted() expr.Option {
+	return expr.Function("mergeSorted", func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		a, err := toList(params[0])
+		if err != nil {
+			return nil, err
+		}
+		b, err := toList(params[1])
+		if err != nil {
+			return nil, err
+		}
+		if err := sameOrderedKind(append(a[:len(a):len(a)], b...)); err != nil {
+			return nil, err
+		}
+
+		out := make([]any, 0, len(a)+len(b))
+		i, j := 0, 0
+		for i < len(a) && j < len(b) {
+			c, err := compareOrdered(b[j], a[i])
+			if err != nil {
+				return nil, err
+			}
+			if c < 0 {
+				out = append(out, b[j])
+				j++
+			} else {
+				out = append(out, a[i])
+				i++
+			}
+		}
+		out = append(out, a[i:]...)
+		return append(out, b[j:]...), nil
+	},
+		new(func([]any, []any) ([]any, error)),
+		new(func([]any, []int) ([]any, error)),
+		new(func([]any, []float64) ([]any, error)),
+		new(func([]any, []string) ([]any, error)),
+		new(func([]int, []any) ([]any, error)),
+		new(func([]int, []int) ([]any, error)),
+		new(func([]int, []float64) ([]any, error)),
+		new(func([]float64, []any) ([]any, error)),
+		new(func([]float64, []int) ([]any, error)),
+		new(func([]float64, []float64) ([]any, error)),
+		new(func([]string, []any) ([]any, error)),
+		new(func([]string, []string) ([]any, error)),
+	)
+}
+
+// sameOrderedKind returns an error unless list holds only numbers or only strings.
+func sameOrderedKind(list []any) error {
+	for i, v := range list {
+		_, isString := v.(string)
+		_, err := toFloat(v)
+		if !isString && err != nil {
+			return fmt.Errorf("element %d: expected a number or a string, got %T", i, v)
+		}
+		if _, first := list[0].(string); isString != first {
+			return fmt.Errorf("element %d: cannot mix %T with %T", i, list[0], v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeSorted(t *testing.T) {
+	env := map[string]any{
+		"ints":    []int{2, 4, 6},
+		"floats":  []float64{1.5, 4.5},
+		"strings": []string{"b", "d"},
+	}
+
+	tests := []struct {
+		name           string
+		exp            string
+		want           []any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "interleaved",
+			exp:  `mergeSorted([1, 4, 7], [2, 3, 8])`,
+			want: []any{1, 2, 3, 4, 7, 8},
+		},
+		{
+			name: "one after the other",
+			exp:  `mergeSorted([4, 5], [1, 2])`,
+			want: []any{1, 2, 4, 5},
+		},
+		{
+			name: "equal elements",
+			exp:  `mergeSorted([1, 2, 2], [2, 3])`,
+			want: []any{1, 2, 2, 2, 3},
+		},
+		{
+			name: "ints and floats",
+			exp:  `mergeSorted([1, 3], [1.5, 2.5])`,
+			want: []any{1, 1.5, 2.5, 3},
+		},
+		{
+			name: "strings",
+			exp:  `mergeSorted(["a", "c"], strings)`,
+			want: []any{"a", "b", "c", "d"},
+		},
+		{
+			name: "typed lists from the environment",
+			exp:  `mergeSorted(ints, ints)`,
+			want: []any{2, 2, 4, 4, 6, 6},
+		},
+		{
+			name: "typed ints and floats",
+			exp:  `mergeSorted(ints, floats)`,
+			want: []any{1.5, 2, 4, 4.5, 6},
+		},
+		{
+			name: "typed floats and ints",
+			exp:  `mergeSorted(floats, ints)`,
+			want: []any{1.5, 2, 4, 4.5, 6},
+		},
+		{
+			name: "first empty",
+			exp:  `mergeSorted([], [1, 2])`,
+			want: []any{1, 2},
+		},
+		{
+			name: "second empty",
+			exp:  `mergeSorted([1, 2], [])`,
+			want: []any{1, 2},
+		},
+		{
+			name: "both empty",
+			exp:  `mergeSorted([], [])`,
+			want: []any{},
+		},
+		{
+			name:           "numbers and strings",
+			exp:            `mergeSorted([1, 2], ["a"])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "mixed list merged with an empty list",
+			exp:            `mergeSorted([1, "a"], [])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "unsupported element",
+			exp:            `mergeSorted([true], [false])`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), MergeSorted())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/expr-lang/expr"
+)
+
+// Mod provides the mod and divmod functions as Expr functions. Unlike Go's (and Expr's) % operator, which takes the
+// sign of the dividend, mod implements Euclidean modulo: the result is always in the range [0, |b|). This makes it
+// suitable for wrapping indexes, e.g. mod(-1, 5) is 4. divmod returns both the Euclidean quotient and remainder as a
+// two element list, satisfying a == b*q + r. Division by zero is an error, as is divmod(math.MinInt, -1), whose
+// quotient does not fit in an int; its remainder does, so mod(math.MinInt, -1) is 0.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Mod())
+//
+// Expression:
+//
+//	mod(-7, 3)    // 2
+//	divmod(-7, 3) // [-3, 2]
+func Mod() expr.Option {
+	return options(
+		expr.Function("mod", func(params ...any) (any, error) {
+			a, b, err := divParams(params)
+			if err != nil {
+				return nil, err
+			}
+			_, r, err := divmod(a, b)
+			if err != nil {
+				return nil, err
+			}
+			return r, nil
+		},
+			new(func(int, int) (int, error)),
+		),
+		expr.Function("divmod", func(params ...any) (any, error) {
+			a, b, err := divParams(params)
+			if err != nil {
+				return nil, err
+			}
+			if a == math.MinInt && b == -1 {
+				return nil, fmt.Errorf("integer overflow: %d / %d does not fit in an int", a, b)
+			}
+			q, r, err := divmod(a, b)
+			if err != nil {
+				return nil, err
+			}
+			return []any{q, r}, nil
+		},
+			new(func(int, int) ([]any, error)),
+		),
+	)
+}
+
+func divParams(params []any) (int, int, error) {
+	if len(params) != 2 {
+		return 0, 0, fmt.Errorf("expected two parameters, got %d", len(params))
+	}
+	a, err := arg[int](params, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	b, err := arg[int](params, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	return a, b, nil
+}
+
+// divmod returns the Euclidean quotient and remainder of a divided by b. As in Go, the quotient of math.MinInt / -1
+// wraps around to math.MinInt, and the remainder is 0.
+func divmod(a, b int) (int, int, error) {
+	if b == 0 {
+		return 0, 0, fmt.Errorf("division by zero")
+	}
+	q, r := a/b, a%b
+	if r < 0 {
+		if b > 0 {
+			q, r = q-1, r+b
+		} else {
+			q, r = q+1, r-b
+		}
+	}
+	return q, r, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMod(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "positive operands",
+			exp:  `mod(7, 3)`,
+			want: 1,
+		},
+		{
+			name: "negative dividend",
+			exp:  `mod(-7, 3)`,
+			want: 2,
+		},
+		{
+			name: "negative dividend differs from %",
+			exp:  `[-7 % 3, mod(-7, 3)]`,
+			want: []any{-1, 2},
+		},
+		{
+			name: "negative divisor",
+			exp:  `mod(7, -3)`,
+			want: 1,
+		},
+		{
+			name: "both negative",
+			exp:  `mod(-7, -3)`,
+			want: 2,
+		},
+		{
+			name: "cyclic index",
+			exp:  `["a", "b", "c"][mod(-1, 3)]`,
+			want: "c",
+		},
+		{
+			name: "divmod - negative dividend",
+			exp:  `divmod(-7, 3)`,
+			want: []any{-3, 2},
+		},
+		{
+			name: "divmod - negative divisor",
+			exp:  `divmod(-7, -3)`,
+			want: []any{3, 2},
+		},
+		{
+			name: "divmod - identity",
+			exp:  `let r = divmod(-17, 5); 5 * r[0] + r[1] == -17`,
+			want: true,
+		},
+		{
+			name:           "mod - division by zero",
+			exp:            `mod(1, 0)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "divmod - division by zero",
+			exp:            `divmod(1, 0)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name: "mod - smallest int by -1",
+			exp:  `mod(-9223372036854775807 - 1, -1)`,
+			want: 0,
+		},
+		{
+			name:           "divmod - smallest int by -1 overflows",
+			exp:            `divmod(-9223372036854775807 - 1, -1)`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(nil), Mod())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, nil)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// MovingAverage provides the movingAverage function as an Expr function. It returns the simple moving averages of a
+// numeric list over a window of the given size. The result has len(list) - window + 1 elements. The window must be
+// positive and no larger than the list.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.MovingAverage())
+//
+// Expression:
+//
+//	movingAverage([1, 2, 3, 4, 5], 3) // [2.0, 3.0, 4.0]
+func MovingAverage() expr.Option {
+	return expr.Function("movingAverage", func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		values, err := toFloats(params[0])
+		if err != nil {
+			return nil, err
+		}
+		window, err := arg[int](params, 1)
+		if err != nil {
+			return nil, err
+		}
+		return movingAverage(values, window)
+	},
+		new(func([]any, int) ([]any, error)),
+		new(func([]int, int) ([]any, error)),
+		new(func([]float64, int) ([]any, error)),
+	)
+}
+
+// movingAverage returns the simple moving averages of values, keeping a running sum so each step is constant time.
+func movingAverage(values []float64, window int) ([]any, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("window must be positive, got %d", window)
+	}
+	if window > len(values) {
+		return nil, fmt.Errorf("window %d is larger than the list length %d", window, len(values))
+	}
+
+	out := make([]any, 0, len(values)-window+1)
+	var sum float64
+	for i, v := range values {
+		sum += v
+		if i >= window {
+			sum -= values[i-window]
+		}
+		if i >= window-1 {
+			out = append(out, sum/float64(window))
+		}
+	}
+	return out, nil
+}
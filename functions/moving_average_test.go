@@ -0,0 +1,84 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMovingAverage(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           []any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "window of 3",
+			exp:  `movingAverage([2, 4, 6, 8, 10, 3], 3)`,
+			want: []any{4.0, 6.0, 8.0, 7.0},
+		},
+		{
+			name: "window of 3 - int slice",
+			exp:  `movingAverage(series, 3)`,
+			want: []any{2.0, 3.0, 4.0},
+		},
+		{
+			name: "window equals length",
+			exp:  `movingAverage(series, 5)`,
+			want: []any{3.0},
+		},
+		{
+			name: "window of 1",
+			exp:  `movingAverage([1.5, 2.5], 1)`,
+			want: []any{1.5, 2.5},
+		},
+		{
+			name:           "window larger than list",
+			exp:            `movingAverage(series, 6)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "zero window",
+			exp:            `movingAverage(series, 0)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "non-numeric element",
+			exp:            `movingAverage([1, "2"], 1)`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{"series": []int{1, 2, 3, 4, 5}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), MovingAverage())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/expr-lang/expr"
+)
+
+// NaturalCompare provides the naturalCompare function as an Expr function. It compares two strings using natural
+// ordering, where embedded runs of digits are compared by their numeric value rather than character by character. It
+// returns -1 if a sorts before b, 1 if a sorts after b, and 0 if they are equal.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.NaturalCompare())
+//
+// Expression:
+//
+//	naturalCompare("2.9", "2.10")      // -1
+//	naturalCompare("file10", "file9")  // 1
+//	naturalCompare("v1.0.0", "v1.0.0") // 0
+func NaturalCompare() expr.Option {
+	return expr.Function("naturalCompare", func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		a, err := arg[string](params, 0)
+		if err != nil {
+			return nil, err
+		}
+		b, err := arg[string](params, 1)
+		if err != nil {
+			return nil, err
+		}
+		return naturalCompare(a, b), nil
+	},
+		new(func(string, string) int),
+	)
+}
+
+// naturalCompare compares a and b, treating each run of digits as a single number. Numbers that are equal in value
+// but differ in leading zeros, such as "01" and "1", fall back to a plain string comparison so the ordering is total.
+func naturalCompare(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if isDigit(a[i]) && isDigit(b[j]) {
+			x, y := digitRun(a[i:]), digitRun(b[j:])
+			if c := compareDigits(x, y); c != 0 {
+				return c
+			}
+			i += len(x)
+			j += len(y)
+			continue
+		}
+		if a[i] != b[j] {
+			if a[i] < b[j] {
+				return -1
+			}
+			return 1
+		}
+		i++
+		j++
+	}
+	switch {
+	case len(a)-i < len(b)-j:
+		return -1
+	case len(a)-i > len(b)-j:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// digitRun returns the leading run of ASCII digits in s.
+func digitRun(s string) string {
+	n := 0
+	for n < len(s) && isDigit(s[n]) {
+		n++
+	}
+	return s[:n]
+}
+
+// compareDigits compares two runs of digits by their numeric value without parsing them, so arbitrarily long runs
+// do not overflow.
+func compareDigits(x, y string) int {
+	x, y = strings.TrimLeft(x, "0"), strings.TrimLeft(y, "0")
+	if len(x) != len(y) {
+		if len(x) < len(y) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(x, y)
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNaturalCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		// Natural ordering disagrees with lexical ordering.
+		{a: "file10", b: "file9", want: 1},
+		{a: "2.10", b: "2.9", want: 1},
+		{a: "v1.2.10", b: "v1.2.9", want: 1},
+		{a: "img100.png", b: "img20.png", want: 1},
+		// Natural ordering agrees with lexical ordering.
+		{a: "file1", b: "file2", want: -1},
+		{a: "abc", b: "abd", want: -1},
+		{a: "v1.0.0", b: "v1.0.0", want: 0},
+		{a: "v1", b: "v1.0", want: -1},
+		{a: "", b: "a", want: -1},
+		// Equal numeric values with differing leading zeros.
+		{a: "file01", b: "file1", want: -1},
+		// Runs longer than an int64.
+		{a: "x123456789012345678901234567890", b: "x99", want: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.a+" vs "+tc.b, func(t *testing.T) {
+			env := map[string]any{"a": tc.a, "b": tc.b}
+			program, err := expr.Compile(`[naturalCompare(a, b), naturalCompare(b, a)]`, expr.Env(env), NaturalCompare())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			require.NoError(t, err)
+			assert.Equal(t, []any{tc.want, -tc.want}, got)
+		})
+	}
+}
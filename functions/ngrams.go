@@ -0,0 +1,99 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/expr-lang/expr"
+)
+
+// Ngrams provides the ngrams and wordNgrams functions as Expr functions. ngrams returns every run of n consecutive
+// characters (runes) in a string, and wordNgrams returns every run of n consecutive whitespace separated words
+// joined by a single space. n must be positive.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Ngrams())
+//
+// Expression:
+//
+//	ngrams("abcd", 2)                  // ["ab", "bc", "cd"]
+//	wordNgrams("the quick brown fox", 3) // ["the quick brown", "quick brown fox"]
+func Ngrams() expr.Option {
+	return options(
+		expr.Function("ngrams", func(params ...any) (any, error) {
+			s, n, err := ngramParams(params)
+			if err != nil {
+				return nil, err
+			}
+			return ngrams(s, n)
+		},
+			new(func(string, int) ([]any, error)),
+		),
+		expr.Function("wordNgrams", func(params ...any) (any, error) {
+			s, n, err := ngramParams(params)
+			if err != nil {
+				return nil, err
+			}
+			return wordNgrams(s, n)
+		},
+			new(func(string, int) ([]any, error)),
+		),
+	)
+}
+
+func ngramParams(params []any) (string, int, error) {
+	if len(params) != 2 {
+		return "", 0, fmt.Errorf("expected two parameters, got %d", len(params))
+	}
+	s, err := arg[string](params, 0)
+	if err != nil {
+		return "", 0, err
+	}
+	n, err := arg[int](params, 1)
+	if err != nil {
+		return "", 0, err
+	}
+	return s, n, nil
+}
+
+// ngrams returns the character n-grams of s. Multibyte characters are treated as a single character.
+func ngrams(s string, n int) ([]any, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("n must be positive, got %d", n)
+	}
+	runes := []rune(s)
+	out := make([]any, 0, max(len(runes)-n+1, 0))
+	for i := 0; i+n <= len(runes); i++ {
+		out = append(out, string(runes[i:i+n]))
+	}
+	return out, nil
+}
+
+// wordNgrams returns the word n-grams of s, each joined by a single space.
+func wordNgrams(s string, n int) ([]any, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("n must be positive, got %d", n)
+	}
+	words := strings.Fields(s)
+	out := make([]any, 0, max(len(words)-n+1, 0))
+	for i := 0; i+n <= len(words); i++ {
+		out = append(out, strings.Join(words[i:i+n], " "))
+	}
+	return out, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNgrams(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           []any
+		wantCompileErr bool
+		wantRuntimeErr bool
+	}{
+		{
+			name: "character bigrams - multibyte",
+			exp:  `ngrams("héllo", 2)`,
+			want: []any{"hé", "él", "ll", "lo"},
+		},
+		{
+			name: "character n larger than string",
+			exp:  `ngrams("ab", 3)`,
+			want: []any{},
+		},
+		{
+			name: "word trigrams",
+			exp:  `wordNgrams("the quick  brown\tfox jumps", 3)`,
+			want: []any{"the quick brown", "quick brown fox", "brown fox jumps"},
+		},
+		{
+			name:           "zero n",
+			exp:            `ngrams("abc", 0)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "negative word n",
+			exp:            `wordNgrams("a b c", -1)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "missing n",
+			exp:            `ngrams("abc")`,
+			wantCompileErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(nil), Ngrams())
+			if tc.wantCompileErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, nil)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/expr-lang/expr"
+)
+
+// Normalize provides the normalize function as an Expr function. It scales a numeric list to the range 0 to 1 using
+// min-max normalization, so the smallest value becomes 0 and the largest becomes 1. When every value is equal there
+// is no range to scale by, and every element of the result is 0. The list must not be empty, and its elements must be
+// finite numbers.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Normalize())
+//
+// Expression:
+//
+//	normalize([10, 15, 20]) // [0.0, 0.5, 1.0]
+//	normalize([3, 3])       // [0.0, 0.0]
+func Normalize() expr.Option {
+	return expr.Function("normalize", func(params ...any) (any, error) {
+		if len(params) != 1 {
+			return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+		}
+		values, err := toFiniteFloats(params[0])
+		if err != nil {
+			return nil, err
+		}
+		return normalize(values)
+	},
+		new(func([]any) ([]any, error)),
+		new(func([]int) ([]any, error)),
+		new(func([]float64) ([]any, error)),
+	)
+}
+
+// normalize min-max scales values to [0, 1].
+func normalize(values []float64) ([]any, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("cannot normalize an empty list")
+	}
+
+	lo, hi := slices.Min(values), slices.Max(values)
+	out := make([]any, len(values))
+	for i, v := range values {
+		if hi == lo {
+			out[i] = 0.0
+			continue
+		}
+		out[i] = (v - lo) / (hi - lo)
+	}
+	return out, nil
+}
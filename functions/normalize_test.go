@@ -0,0 +1,84 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           []any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "typical",
+			exp:  `normalize([50, 0, 100, 25])`,
+			want: []any{0.5, 0.0, 1.0, 0.25},
+		},
+		{
+			name: "negative and float values",
+			exp:  `normalize([-2, 0.0, 2])`,
+			want: []any{0.0, 0.5, 1.0},
+		},
+		{
+			name: "int slice",
+			exp:  `normalize(scores)`,
+			want: []any{0.0, 0.75, 1.0},
+		},
+		{
+			name: "all equal",
+			exp:  `normalize([7, 7, 7])`,
+			want: []any{0.0, 0.0, 0.0},
+		},
+		{
+			name:           "NaN element",
+			exp:            `normalize([1, 0 / 0, 3])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "infinite element",
+			exp:            `normalize([1, -1 / 0])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "empty",
+			exp:            `normalize([])`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{"scores": []int{10, 40, 50}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Normalize())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
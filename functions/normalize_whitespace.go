@@ -0,0 +1,74 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/expr-lang/expr"
+)
+
+// blankLines matches a line break followed by one or more lines containing only whitespace.
+var blankLines = regexp.MustCompile(`\n(?:[ \t\r\f\v]*\n)+`)
+
+// NormalizeWhitespace provides the normalizeWhitespace and collapseNewlines functions as Expr functions.
+// normalizeWhitespace trims a string and collapses every internal run of whitespace (spaces, tabs, newlines) into a
+// single space. collapseNewlines leaves other whitespace alone and only collapses runs of blank lines into a single
+// line break.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.NormalizeWhitespace())
+//
+// Expression:
+//
+//	normalizeWhitespace("  hello \t\n world ") // "hello world"
+//	collapseNewlines("a\n\n\nb")               // "a\nb"
+func NormalizeWhitespace() expr.Option {
+	return options(
+		expr.Function("normalizeWhitespace", func(params ...any) (any, error) {
+			if len(params) != 1 {
+				return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+			}
+			s, err := arg[string](params, 0)
+			if err != nil {
+				return nil, err
+			}
+			return normalizeWhitespace(s), nil
+		},
+			new(func(string) string),
+		),
+		expr.Function("collapseNewlines", func(params ...any) (any, error) {
+			if len(params) != 1 {
+				return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+			}
+			s, err := arg[string](params, 0)
+			if err != nil {
+				return nil, err
+			}
+			return blankLines.ReplaceAllString(s, "\n"), nil
+		},
+			new(func(string) string),
+		),
+	)
+}
+
+// normalizeWhitespace trims s and replaces each run of whitespace with a single space.
+func normalizeWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeWhitespace(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want string
+	}{
+		{
+			name: "mixed whitespace",
+			exp:  `normalizeWhitespace(" \t hello \n\n  big\t\tworld \r\n")`,
+			want: "hello big world",
+		},
+		{
+			name: "already normalized",
+			exp:  `normalizeWhitespace("hello big world")`,
+			want: "hello big world",
+		},
+		{
+			name: "only whitespace",
+			exp:  `normalizeWhitespace(" \t\n ")`,
+			want: "",
+		},
+		{
+			name: "collapse blank lines",
+			exp:  `collapseNewlines("a\n\n  \n\tb\n\nc  d")`,
+			want: "a\n\tb\nc  d",
+		},
+		{
+			name: "collapse blank lines - windows line endings",
+			exp:  `collapseNewlines("a\r\n\r\nb")`,
+			want: "a\r\nb",
+		},
+		{
+			name: "collapse blank lines - already normalized",
+			exp:  `collapseNewlines("a\nb")`,
+			want: "a\nb",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(nil), NormalizeWhitespace())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
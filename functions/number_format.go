@@ -0,0 +1,100 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/expr-lang/expr"
+)
+
+// NumberFormat provides the numberFormat function as an Expr function. It formats a number with grouped thousands.
+// With a single argument, thousands are separated by commas and the number is printed with as many decimals as it
+// needs, so whole numbers have none. The four argument form takes the number of decimals to round to, at most 100 as
+// for toFixed, the thousands separator, and the decimal separator.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.NumberFormat())
+//
+// Expression:
+//
+//	numberFormat(1234567)                  // "1,234,567"
+//	numberFormat(-1234.5)                  // "-1,234.5"
+//	numberFormat(1234567.891, 2, ".", ",") // "1.234.567,89"
+func NumberFormat() expr.Option {
+	return expr.Function("numberFormat", func(params ...any) (any, error) {
+		if len(params) != 1 && len(params) != 4 {
+			return nil, fmt.Errorf("expected one or four parameters, got %d", len(params))
+		}
+		n, err := argFloat(params, 0)
+		if err != nil {
+			return nil, err
+		}
+		if len(params) == 1 {
+			return numberFormat(n, -1, ",", "."), nil
+		}
+		decimals, err := arg[int](params, 1)
+		if err != nil {
+			return nil, err
+		}
+		if decimals < 0 || decimals > maxFixedPlaces {
+			return nil, fmt.Errorf("decimals must be between 0 and %d, got %d", maxFixedPlaces, decimals)
+		}
+		thousandsSep, err := arg[string](params, 2)
+		if err != nil {
+			return nil, err
+		}
+		decimalSep, err := arg[string](params, 3)
+		if err != nil {
+			return nil, err
+		}
+		return numberFormat(n, decimals, thousandsSep, decimalSep), nil
+	},
+		new(func(float64) (string, error)),
+		new(func(float64, int, string, string) (string, error)),
+	)
+}
+
+// numberFormat formats n rounded to decimals places, or the fewest places needed when decimals is -1.
+func numberFormat(n float64, decimals int, thousandsSep, decimalSep string) string {
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		return strconv.FormatFloat(n, 'f', decimals, 64)
+	}
+
+	s := strconv.FormatFloat(math.Abs(n), 'f', decimals, 64)
+	intPart, fracPart, hasFrac := strings.Cut(s, ".")
+
+	var b strings.Builder
+	// Avoid printing "-0" when a small negative number rounds to zero.
+	if n < 0 && strings.Trim(s, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(thousandsSep)
+		}
+		b.WriteRune(c)
+	}
+	if hasFrac {
+		b.WriteString(decimalSep)
+		b.WriteString(fracPart)
+	}
+	return b.String()
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumberFormat(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           string
+		wantRuntimeErr bool
+	}{
+		{
+			name: "small",
+			exp:  `numberFormat(999)`,
+			want: "999",
+		},
+		{
+			name: "large",
+			exp:  `numberFormat(1234567890)`,
+			want: "1,234,567,890",
+		},
+		{
+			name: "large from environment",
+			exp:  `numberFormat(count)`,
+			want: "12,345,678",
+		},
+		{
+			name: "negative",
+			exp:  `numberFormat(-1234567)`,
+			want: "-1,234,567",
+		},
+		{
+			name: "fraction",
+			exp:  `numberFormat(1234.5)`,
+			want: "1,234.5",
+		},
+		{
+			name: "fixed decimals",
+			exp:  `numberFormat(1234567.891, 2, ",", ".")`,
+			want: "1,234,567.89",
+		},
+		{
+			name: "custom separators",
+			exp:  `numberFormat(-1234567.891, 2, ".", ",")`,
+			want: "-1.234.567,89",
+		},
+		{
+			name: "space separator and padding",
+			exp:  `numberFormat(1000, 3, " ", ",")`,
+			want: "1 000,000",
+		},
+		{
+			name: "negative rounds to zero",
+			exp:  `numberFormat(-0.001, 2, ",", ".")`,
+			want: "0.00",
+		},
+		{
+			name: "most decimals",
+			exp:  `numberFormat(1.5, 100, ",", ".")[:4]`,
+			want: "1.50",
+		},
+		{
+			name:           "too many decimals",
+			exp:            `numberFormat(1, 101, ",", ".")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "negative decimals",
+			exp:            `numberFormat(1, -1, ",", ".")`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{"count": 12345678}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), NumberFormat())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
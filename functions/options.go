@@ -0,0 +1,29 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/conf"
+)
+
+// options combines several Expr options into one so that a family of related functions can be injected together.
+func options(opts ...expr.Option) expr.Option {
+	return func(c *conf.Config) {
+		for _, opt := range opts {
+			opt(c)
+		}
+	}
+}
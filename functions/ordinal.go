@@ -0,0 +1,73 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/expr-lang/expr"
+)
+
+// Ordinal provides the ordinal function as an Expr function. It returns the English ordinal form of an integer, such
+// as "1st", "2nd", "3rd", and "4th", including the "th" exceptions for numbers ending in 11, 12, and 13.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Ordinal())
+//
+// Expression:
+//
+//	ordinal(1)   // "1st"
+//	ordinal(12)  // "12th"
+//	ordinal(103) // "103rd"
+func Ordinal() expr.Option {
+	return expr.Function("ordinal", func(params ...any) (any, error) {
+		if len(params) != 1 {
+			return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+		}
+		n, err := arg[int](params, 0)
+		if err != nil {
+			return nil, err
+		}
+		return ordinal(n), nil
+	},
+		new(func(int) (string, error)),
+	)
+}
+
+// ordinal returns n followed by its English ordinal suffix.
+func ordinal(n int) string {
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+
+	suffix := "th"
+	switch abs % 100 {
+	case 11, 12, 13:
+	default:
+		switch abs % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
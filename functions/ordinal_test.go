@@ -0,0 +1,60 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrdinal(t *testing.T) {
+	tests := map[int]string{
+		0:    "0th",
+		1:    "1st",
+		2:    "2nd",
+		3:    "3rd",
+		4:    "4th",
+		10:   "10th",
+		11:   "11th",
+		12:   "12th",
+		13:   "13th",
+		14:   "14th",
+		21:   "21st",
+		22:   "22nd",
+		23:   "23rd",
+		101:  "101st",
+		111:  "111th",
+		112:  "112th",
+		113:  "113th",
+		1002: "1002nd",
+		-1:   "-1st",
+		-11:  "-11th",
+	}
+
+	for n, want := range tests {
+		t.Run(want, func(t *testing.T) {
+			env := map[string]any{"n": n}
+			program, err := expr.Compile(`ordinal(n)`, expr.Env(env), Ordinal())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/expr-lang/expr/vm"
+)
+
+// memoryBudget returns the number of elements a function may allocate for its result. Some functions, such as
+// cartesianProduct, produce results that grow much faster than their inputs, so they are held to vm.MemoryBudget, the
+// limit the Expr VM enforces on the elements of the lists and maps an expression builds itself.
+func memoryBudget() int {
+	if vm.MemoryBudget > math.MaxInt {
+		return math.MaxInt
+	}
+	return int(vm.MemoryBudget)
+}
+
+// arg returns the i-th parameter as type T. Expr type checks calls against the registered signatures at compile
+// time, but values typed as any in the environment are only known at runtime, so mis-typed values are surfaced as
+// errors rather than panics.
+func arg[T any](params []any, i int) (T, error) {
+	var r T
+	if i >= len(params) {
+		return r, fmt.Errorf("missing parameter %d", i+1)
+	}
+	v, ok := params[i].(T)
+	if !ok {
+		return r, fmt.Errorf("parameter %d: expected %T, got %T", i+1, r, params[i])
+	}
+	return v, nil
+}
+
+// argFloat returns the i-th parameter as a float64, converting from any of the Go numeric types. Integer literals are
+// converted to floats by the Expr type checker, but integers held in the environment are not.
+func argFloat(params []any, i int) (float64, error) {
+	if i >= len(params) {
+		return 0, fmt.Errorf("missing parameter %d", i+1)
+	}
+	f, err := toFloat(params[i])
+	if err != nil {
+		return 0, fmt.Errorf("parameter %d: %w", i+1, err)
+	}
+	return f, nil
+}
+
+// toFloat converts any of the Go numeric types to a float64.
+func toFloat(v any) (float64, error) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), nil
+	case int8:
+		return float64(n), nil
+	case int16:
+		return float64(n), nil
+	case int32:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case uint:
+		return float64(n), nil
+	case uint8:
+		return float64(n), nil
+	case uint16:
+		return float64(n), nil
+	case uint32:
+		return float64(n), nil
+	case uint64:
+		return float64(n), nil
+	case float32:
+		return float64(n), nil
+	case float64:
+		return n, nil
+	}
+	return 0, fmt.Errorf("expected a number, got %T", v)
+}
+
+// toList converts any slice or array to a []any. Expr produces []any for list literals, but values from the
+// environment may be typed slices such as []int or []string. A nil value, such as a missing field, is not a list.
+func toList(v any) ([]any, error) {
+	if l, ok := v.([]any); ok {
+		return l, nil
+	}
+	if v == nil {
+		return nil, fmt.Errorf("expected a list, got nil")
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("type %s is not a list", reflect.TypeOf(v))
+	}
+	out := make([]any, rv.Len())
+	for i := range out {
+		out[i] = rv.Index(i).Interface()
+	}
+	return out, nil
+}
+
+// argFiniteFloat is like argFloat, but also rejects NaN and infinite numbers. They cannot be marshaled to JSON, so a
+// result computed from them would only fail once it is returned to the playground.
+func argFiniteFloat(params []any, i int) (float64, error) {
+	f, err := argFloat(params, i)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("parameter %d: %v is not a finite number", i+1, f)
+	}
+	return f, nil
+}
+
+// toFiniteFloats is like toFloats, but also rejects NaN and infinite elements, for the same reason as argFiniteFloat.
+func toFiniteFloats(v any) ([]float64, error) {
+	out, err := toFloats(v)
+	if err != nil {
+		return nil, err
+	}
+	for i, f := range out {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("element %d: %v is not a finite number", i, f)
+		}
+	}
+	return out, nil
+}
+
+// toFloats converts a list of numbers of any of the Go numeric types to a []float64.
+func toFloats(v any) ([]float64, error) {
+	list, err := toList(v)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]float64, len(list))
+	for i, e := range list {
+		f, err := toFloat(e)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		out[i] = f
+	}
+	return out, nil
+}
+
+// toInt converts any of the Go integer types to an int.
+func toInt(v any) (int, error) {
+	switch n := v.(type) {
+	case int:
+		return n, nil
+	case int8:
+		return int(n), nil
+	case int16:
+		return int(n), nil
+	case int32:
+		return int(n), nil
+	case int64:
+		return int(n), nil
+	case uint:
+		return int(n), nil
+	case uint8:
+		return int(n), nil
+	case uint16:
+		return int(n), nil
+	case uint32:
+		return int(n), nil
+	case uint64:
+		return int(n), nil
+	}
+	return 0, fmt.Errorf("expected an integer, got %T", v)
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/expr-lang/expr"
+)
+
+// decimalNumber matches a plain decimal number once the thousands separators have been removed.
+var decimalNumber = regexp.MustCompile(`^[+-]?(?:[0-9]+\.?[0-9]*|\.[0-9]+)(?:[eE][+-]?[0-9]+)?$`)
+
+// ParseNumber provides the parseNumber function as an Expr function. It is the inverse of numberFormat: thousands
+// separators (commas, periods, and spaces other than the decimal separator) are removed and the remainder is parsed as
+// a float. The decimal separator defaults to "." and can be set to "," for European style input.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.ParseNumber())
+//
+// Expression:
+//
+//	parseNumber("1,234,567.89")      // 1234567.89
+//	parseNumber("1 234")             // 1234
+//	parseNumber("1.234.567,89", ",") // 1234567.89
+func ParseNumber() expr.Option {
+	return expr.Function("parseNumber", func(params ...any) (any, error) {
+		if len(params) < 1 || len(params) > 2 {
+			return nil, fmt.Errorf("expected one or two parameters, got %d", len(params))
+		}
+		s, err := arg[string](params, 0)
+		if err != nil {
+			return nil, err
+		}
+		decimalSep := "."
+		if len(params) == 2 {
+			if decimalSep, err = arg[string](params, 1); err != nil {
+				return nil, err
+			}
+		}
+		return parseNumber(s, decimalSep)
+	},
+		new(func(string) (float64, error)),
+		new(func(string, string) (float64, error)),
+	)
+}
+
+// parseNumber parses s as a float, using decimalSep as the decimal separator and ignoring thousands separators.
+func parseNumber(s, decimalSep string) (float64, error) {
+	if decimalSep != "." && decimalSep != "," {
+		return 0, fmt.Errorf("unsupported decimal separator %q, expected \".\" or \",\"", decimalSep)
+	}
+
+	n := strings.TrimSpace(s)
+	for _, sep := range []string{",", ".", " ", "\u00a0", "\u202f"} {
+		if sep != decimalSep {
+			n = strings.ReplaceAll(n, sep, "")
+		}
+	}
+	n = strings.Replace(n, decimalSep, ".", 1)
+
+	if !decimalNumber.MatchString(n) {
+		return 0, fmt.Errorf("unable to parse %q as a number", s)
+	}
+	f, err := strconv.ParseFloat(n, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse %q as a number: %w", s, err)
+	}
+	return f, nil
+}
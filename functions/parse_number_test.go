@@ -0,0 +1,103 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           float64
+		wantRuntimeErr bool
+	}{
+		{
+			name: "comma grouped",
+			exp:  `parseNumber("1,234,567.89")`,
+			want: 1234567.89,
+		},
+		{
+			name: "space grouped",
+			exp:  `parseNumber(" 1 234 567 ")`,
+			want: 1234567,
+		},
+		{
+			name: "negative",
+			exp:  `parseNumber("-12,000")`,
+			want: -12000,
+		},
+		{
+			name: "european decimal comma",
+			exp:  `parseNumber("1.234.567,89", ",")`,
+			want: 1234567.89,
+		},
+		{
+			name: "european decimal comma - no grouping",
+			exp:  `parseNumber("3,5", ",")`,
+			want: 3.5,
+		},
+		{
+			name: "round trip",
+			exp:  `parseNumber(numberFormat(-9876543.21, 2, ".", ","), ",")`,
+			want: -9876543.21,
+		},
+		{
+			name:           "invalid string",
+			exp:            `parseNumber("twelve")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "not a number",
+			exp:            `parseNumber("NaN")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "empty",
+			exp:            `parseNumber("")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "two decimal separators",
+			exp:            `parseNumber("1,5,5", ",")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "unsupported decimal separator",
+			exp:            `parseNumber("1;5", ";")`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(nil), ParseNumber(), NumberFormat())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, nil)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
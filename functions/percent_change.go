@@ -0,0 +1,57 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// PercentChange provides the percentChange function as an Expr function. It returns the signed percentage change
+// from one value to another, ((to - from) / from) * 100. A change from zero is undefined and returns an error, as do
+// NaN and infinite values and changes too large for a float.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.PercentChange())
+//
+// Expression:
+//
+//	percentChange(100, 120) // 20.0
+//	percentChange(50, 25)   // -50.0
+//	percentChange(cost.previous, cost.current) <= 20
+func PercentChange() expr.Option {
+	return expr.Function("percentChange", func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		from, err := argFiniteFloat(params, 0)
+		if err != nil {
+			return nil, err
+		}
+		to, err := argFiniteFloat(params, 1)
+		if err != nil {
+			return nil, err
+		}
+		if from == 0 {
+			return nil, fmt.Errorf("percent change from zero is undefined")
+		}
+		return finite("percentChange", (to-from)/from*100)
+	},
+		new(func(float64, float64) (float64, error)),
+	)
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentChange(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "increase",
+			exp:  `percentChange(100, 125)`,
+			want: 25.0,
+		},
+		{
+			name: "decrease",
+			exp:  `percentChange(80, 20)`,
+			want: -75.0,
+		},
+		{
+			name: "no change",
+			exp:  `percentChange(3.5, 3.5)`,
+			want: 0.0,
+		},
+		{
+			name: "threshold policy",
+			exp:  `percentChange(cost.previous, cost.current) > 20`,
+			want: true,
+		},
+		{
+			name:           "zero base",
+			exp:            `percentChange(0, 10)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "NaN value",
+			exp:            `percentChange(100, 0 / 0)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "infinite base",
+			exp:            `percentChange(1 / 0, 10)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "change overflows",
+			exp:            `percentChange(1e-300, 1e300)`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{"cost": map[string]any{"previous": 200, "current": 250}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), PercentChange())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/expr-lang/expr"
+)
+
+// Percentile provides the percentile function as an Expr function. It returns the p-th percentile (0 to 100) of a
+// list of numbers, linearly interpolating between the closest ranks. This is the same method used by NumPy's default
+// and by spreadsheet PERCENTILE functions. The list must not be empty, and its elements must be finite numbers.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Percentile())
+//
+// Expression:
+//
+//	percentile([1, 2, 3, 4], 50) // 2.5
+//	percentile(latencies, 99) < 250
+func Percentile() expr.Option {
+	return expr.Function("percentile", func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		values, err := toFiniteFloats(params[0])
+		if err != nil {
+			return nil, err
+		}
+		p, err := argFloat(params, 1)
+		if err != nil {
+			return nil, err
+		}
+		return percentile(values, p)
+	},
+		new(func([]any, float64) (float64, error)),
+		new(func([]int, float64) (float64, error)),
+		new(func([]float64, float64) (float64, error)),
+	)
+}
+
+// percentile returns the p-th percentile of values using linear interpolation. values is not modified.
+func percentile(values []float64, p float64) (float64, error) {
+	if len(values) == 0 {
+		return 0, fmt.Errorf("percentile of an empty list")
+	}
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, fmt.Errorf("percentile must be between 0 and 100, got %v", p)
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(rank-float64(lo)), nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           float64
+		wantCompileErr bool
+		wantRuntimeErr bool
+	}{
+		{
+			name: "p50",
+			exp:  `percentile(latencies, 50)`,
+			want: 55,
+		},
+		{
+			name: "p90",
+			exp:  `percentile(latencies, 90)`,
+			want: 91,
+		},
+		{
+			name: "p100",
+			exp:  `percentile(latencies, 100)`,
+			want: 100,
+		},
+		{
+			name: "p0",
+			exp:  `percentile(latencies, 0)`,
+			want: 10,
+		},
+		{
+			name: "fractional percentile",
+			exp:  `percentile(latencies, 12.5)`,
+			want: 21.25,
+		},
+		{
+			name: "float slice",
+			exp:  `percentile(floats, 50)`,
+			want: 2.5,
+		},
+		{
+			name: "mixed list literal",
+			exp:  `percentile([4, 1.0, 3, 2], 75)`,
+			want: 3.25,
+		},
+		{
+			name: "single element",
+			exp:  `percentile([7], 99)`,
+			want: 7,
+		},
+		{
+			name:           "empty list",
+			exp:            `percentile([], 50)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "out of range",
+			exp:            `percentile(latencies, 101)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "negative",
+			exp:            `percentile(latencies, -1)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "non-numeric element",
+			exp:            `percentile([1, "2"], 50)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "NaN element",
+			exp:            `percentile([1, 0 / 0], 50)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "infinite element",
+			exp:            `percentile([1, 1 / 0], 50)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "not a list",
+			exp:            `percentile(1, 50)`,
+			wantCompileErr: true,
+		},
+	}
+
+	env := map[string]any{
+		// Deliberately unsorted.
+		"latencies": []int{100, 20, 30, 10, 50, 40, 60, 90, 80, 70},
+		"floats":    []float64{4, 1, 3, 2},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Percentile())
+			if tc.wantCompileErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tc.want, got, 1e-9)
+		})
+	}
+}
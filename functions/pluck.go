@@ -0,0 +1,80 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// Pluck provides the pluck function as an Expr function. It returns the value of a field from each map in a list.
+// Elements that are not maps or do not have the field are skipped, unless the optional strict argument is true, in
+// which case they are an error. This is handy when the field name is held in a variable, where map(list, #.key) would
+// not work.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Pluck())
+//
+// Expression:
+//
+//	pluck([{"id": 1}, {"id": 2}, {}], "id")       // [1, 2]
+//	pluck([{"id": 1}, {"id": 2}, {}], "id", true) // error
+func Pluck() expr.Option {
+	return expr.Function("pluck", func(params ...any) (any, error) {
+		if len(params) < 2 || len(params) > 3 {
+			return nil, fmt.Errorf("expected two or three parameters, got %d", len(params))
+		}
+		list, err := toList(params[0])
+		if err != nil {
+			return nil, err
+		}
+		key, err := arg[string](params, 1)
+		if err != nil {
+			return nil, err
+		}
+		var strict bool
+		if len(params) == 3 {
+			if strict, err = arg[bool](params, 2); err != nil {
+				return nil, err
+			}
+		}
+
+		out := make([]any, 0, len(list))
+		for i, v := range list {
+			m, ok := v.(map[string]any)
+			if !ok {
+				if strict {
+					return nil, fmt.Errorf("element %d: expected a map, got %T", i, v)
+				}
+				continue
+			}
+			fv, ok := m[key]
+			if !ok {
+				if strict {
+					return nil, fmt.Errorf("element %d: missing key %q", i, key)
+				}
+				continue
+			}
+			out = append(out, fv)
+		}
+		return out, nil
+	},
+		new(func([]any, string) ([]any, error)),
+		new(func([]any, string, bool) ([]any, error)),
+	)
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluck(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           []any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "present keys",
+			exp:  `pluck([{"id": 1, "n": "a"}, {"id": 2, "n": "b"}], "n")`,
+			want: []any{"a", "b"},
+		},
+		{
+			name: "dynamic key from the environment",
+			exp:  `pluck(rows, column)`,
+			want: []any{"us-east", "eu-west"},
+		},
+		{
+			name: "missing key skipped",
+			exp:  `pluck([{"id": 1}, {"name": "x"}, {"id": 3}], "id")`,
+			want: []any{1, 3},
+		},
+		{
+			name: "non-map element skipped",
+			exp:  `pluck([{"id": 1}, "x"], "id")`,
+			want: []any{1},
+		},
+		{
+			name: "strict with all keys present",
+			exp:  `pluck([{"id": 1}, {"id": 2}], "id", true)`,
+			want: []any{1, 2},
+		},
+		{
+			name: "explicitly lenient",
+			exp:  `pluck([{"id": 1}, {}], "id", false)`,
+			want: []any{1},
+		},
+		{
+			name:           "strict missing key",
+			exp:            `pluck([{"id": 1}, {"name": "x"}], "id", true)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "strict non-map element",
+			exp:            `pluck([{"id": 1}, 2], "id", true)`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{
+		"column": "region",
+		"rows": []any{
+			map[string]any{"region": "us-east"},
+			map[string]any{"region": "eu-west"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Pluck())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// Pluralize provides the pluralize function as an Expr function. It returns the singular word when the count is
+// exactly one (or negative one), and the plural word otherwise. When no plural is given, "s" is appended to the
+// singular.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Pluralize())
+//
+// Expression:
+//
+//	pluralize(1, "pod")                // "pod"
+//	pluralize(3, "pod")                // "pods"
+//	pluralize(0, "policy", "policies") // "policies"
+func Pluralize() expr.Option {
+	return expr.Function("pluralize", func(params ...any) (any, error) {
+		if len(params) < 2 || len(params) > 3 {
+			return nil, fmt.Errorf("expected two or three parameters, got %d", len(params))
+		}
+		count, err := arg[int](params, 0)
+		if err != nil {
+			return nil, err
+		}
+		singular, err := arg[string](params, 1)
+		if err != nil {
+			return nil, err
+		}
+		plural := singular + "s"
+		if len(params) == 3 {
+			if plural, err = arg[string](params, 2); err != nil {
+				return nil, err
+			}
+		}
+		if count == 1 || count == -1 {
+			return singular, nil
+		}
+		return plural, nil
+	},
+		new(func(int, string) (string, error)),
+		new(func(int, string, string) (string, error)),
+	)
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluralize(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want string
+	}{
+		{
+			name: "zero",
+			exp:  `pluralize(0, "policy", "policies")`,
+			want: "policies",
+		},
+		{
+			name: "one",
+			exp:  `pluralize(1, "policy", "policies")`,
+			want: "policy",
+		},
+		{
+			name: "many",
+			exp:  `pluralize(42, "policy", "policies")`,
+			want: "policies",
+		},
+		{
+			name: "default plural - one",
+			exp:  `pluralize(1, "container")`,
+			want: "container",
+		},
+		{
+			name: "default plural - many",
+			exp:  `pluralize(3, "container")`,
+			want: "containers",
+		},
+		{
+			name: "message",
+			exp:  `string(len(items)) + " " + pluralize(len(items), "item")`,
+			want: "2 items",
+		},
+	}
+
+	env := map[string]any{"items": []int{1, 2}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Pluralize())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/expr-lang/expr"
+)
+
+// Primes provides the isPrime and nextPrime functions as Expr functions. isPrime reports whether an integer is
+// prime, and is false for every value below 2. nextPrime returns the smallest prime strictly greater than n.
+//
+// Primality is tested with big.Int.ProbablyPrime, which applies the Baillie-PSW test. Baillie-PSW has no known
+// pseudoprimes and has been verified to be exact for all 64-bit integers, so the results are deterministic.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Primes())
+//
+// Expression:
+//
+//	isPrime(7)   // true
+//	isPrime(1)   // false
+//	nextPrime(7) // 11
+func Primes() expr.Option {
+	return options(
+		expr.Function("isPrime", func(params ...any) (any, error) {
+			if len(params) != 1 {
+				return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+			}
+			n, err := arg[int](params, 0)
+			if err != nil {
+				return nil, err
+			}
+			return isPrime(n), nil
+		},
+			new(func(int) (bool, error)),
+		),
+		expr.Function("nextPrime", func(params ...any) (any, error) {
+			if len(params) != 1 {
+				return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+			}
+			n, err := arg[int](params, 0)
+			if err != nil {
+				return nil, err
+			}
+			return nextPrime(n)
+		},
+			new(func(int) (int, error)),
+		),
+	)
+}
+
+// isPrime reports whether n is prime.
+func isPrime(n int) bool {
+	if n < 2 {
+		return false
+	}
+	return big.NewInt(int64(n)).ProbablyPrime(0)
+}
+
+// nextPrime returns the smallest prime greater than n, or an error if there is no such prime that fits in an int.
+func nextPrime(n int) (int, error) {
+	if n < 2 {
+		return 2, nil
+	}
+	for c := n; c < math.MaxInt; {
+		c++
+		if isPrime(c) {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("integer overflow: no prime greater than %d fits in an int", n)
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_isPrime(t *testing.T) {
+	var primes []int
+	for n := -5; n < 50; n++ {
+		if isPrime(n) {
+			primes = append(primes, n)
+		}
+	}
+	assert.Equal(t, []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47}, primes)
+}
+
+func TestPrimes(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "small prime",
+			exp:  `isPrime(13)`,
+			want: true,
+		},
+		{
+			name: "composite",
+			exp:  `isPrime(91)`,
+			want: false,
+		},
+		{
+			name: "carmichael number",
+			exp:  `isPrime(561)`,
+			want: false,
+		},
+		{
+			name: "one",
+			exp:  `isPrime(1)`,
+			want: false,
+		},
+		{
+			name: "negative",
+			exp:  `isPrime(-7)`,
+			want: false,
+		},
+		{
+			name: "large prime",
+			exp:  `isPrime(9223372036854775783)`,
+			want: true,
+		},
+		{
+			name: "large composite",
+			exp:  `isPrime(9223372036854775807)`,
+			want: false,
+		},
+		{
+			name: "next prime",
+			exp:  `nextPrime(13)`,
+			want: 17,
+		},
+		{
+			name: "next prime - below two",
+			exp:  `nextPrime(-10)`,
+			want: 2,
+		},
+		{
+			name: "next prime - large",
+			exp:  `nextPrime(1000000000)`,
+			want: 1000000007,
+		},
+		{
+			name:           "next prime - overflow",
+			exp:            `nextPrime(9223372036854775783)`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(nil), Primes())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, nil)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/expr-lang/expr"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ParsedQuantity is the value returned by the quantity function, a Kubernetes resource quantity such as "1.3G" or
+// "500Mi". Like ParsedURL, its methods are fields so that they can have the lowercase names used in expressions.
+// Arithmetic returns a new ParsedQuantity, so calls can be chained, as in quantity(a).add(b).sub(1). The arguments of
+// the arithmetic and comparison methods may be another quantity or a number. A ParsedQuantity marshals to JSON as
+// its canonical string, such as "2G".
+type ParsedQuantity struct {
+	// Add returns the sum of the quantity and the argument.
+	Add func(any) (ParsedQuantity, error) `expr:"add" json:"-"`
+	// Sub returns the quantity minus the argument.
+	Sub func(any) (ParsedQuantity, error) `expr:"sub" json:"-"`
+	// IsLessThan reports whether the quantity is less than the argument.
+	IsLessThan func(any) (bool, error) `expr:"isLessThan" json:"-"`
+	// IsGreaterThan reports whether the quantity is greater than the argument.
+	IsGreaterThan func(any) (bool, error) `expr:"isGreaterThan" json:"-"`
+	// IsEqualTo reports whether the quantity is equal to the argument.
+	IsEqualTo func(any) (bool, error) `expr:"isEqualTo" json:"-"`
+	// AsInteger returns the quantity as an integer. It is an error if the quantity has a fractional part or does not
+	// fit in an int64.
+	AsInteger func() (int, error) `expr:"asInteger" json:"-"`
+
+	q resource.Quantity
+}
+
+// MarshalJSON encodes the quantity as its canonical string.
+func (p ParsedQuantity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.q.String())
+}
+
+// Quantity provides the quantity and isQuantity functions as Expr functions. quantity parses a Kubernetes resource
+// quantity string into a ParsedQuantity, and is an error when the string is not a valid quantity. isQuantity reports
+// whether a string is a valid quantity.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Quantity())
+//
+// Expression:
+//
+//	quantity("1.3G").add(quantity("700M")).isEqualTo(quantity("2G")) // true
+//	quantity("1Ki").sub(24).asInteger()                              // 1000
+//	isQuantity("500Mi")                                              // true
+func Quantity() expr.Option {
+	return options(
+		expr.Function("quantity", func(params ...any) (any, error) {
+			if len(params) != 1 {
+				return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+			}
+			s, err := arg[string](params, 0)
+			if err != nil {
+				return nil, err
+			}
+			q, err := resource.ParseQuantity(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid quantity %q: %w", s, err)
+			}
+			return newParsedQuantity(q), nil
+		},
+			new(func(string) (ParsedQuantity, error)),
+		),
+		expr.Function("isQuantity", func(params ...any) (any, error) {
+			if len(params) != 1 {
+				return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+			}
+			s, err := arg[string](params, 0)
+			if err != nil {
+				return nil, err
+			}
+			_, err = resource.ParseQuantity(s)
+			return err == nil, nil
+		},
+			new(func(string) (bool, error)),
+		),
+	)
+}
+
+func newParsedQuantity(q resource.Quantity) ParsedQuantity {
+	compare := func(v any) (int, error) {
+		other, err := toQuantity(v)
+		if err != nil {
+			return 0, err
+		}
+		return q.Cmp(other), nil
+	}
+	return ParsedQuantity{
+		Add: func(v any) (ParsedQuantity, error) {
+			other, err := toQuantity(v)
+			if err != nil {
+				return ParsedQuantity{}, err
+			}
+			sum := q.DeepCopy()
+			sum.Add(other)
+			return newParsedQuantity(sum), nil
+		},
+		Sub: func(v any) (ParsedQuantity, error) {
+			other, err := toQuantity(v)
+			if err != nil {
+				return ParsedQuantity{}, err
+			}
+			diff := q.DeepCopy()
+			diff.Sub(other)
+			return newParsedQuantity(diff), nil
+		},
+		IsLessThan: func(v any) (bool, error) {
+			c, err := compare(v)
+			return c < 0, err
+		},
+		IsGreaterThan: func(v any) (bool, error) {
+			c, err := compare(v)
+			return c > 0, err
+		},
+		IsEqualTo: func(v any) (bool, error) {
+			c, err := compare(v)
+			return c == 0, err
+		},
+		AsInteger: func() (int, error) {
+			n, ok := q.AsInt64()
+			if !ok {
+				return 0, fmt.Errorf("quantity %s is not an integer that fits in an int64", q.String())
+			}
+			return int(n), nil
+		},
+		q: q,
+	}
+}
+
+// toQuantity converts v, a ParsedQuantity or a number, to a resource.Quantity.
+func toQuantity(v any) (resource.Quantity, error) {
+	if p, ok := v.(ParsedQuantity); ok {
+		return p.q, nil
+	}
+	if n, err := toInt(v); err == nil {
+		return *resource.NewQuantity(int64(n), resource.DecimalSI), nil
+	}
+	f, err := toFloat(v)
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("expected a quantity or a number, got %T", v)
+	}
+	q, err := resource.ParseQuantity(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("%v cannot be used as a quantity: %w", f, err)
+	}
+	return q, nil
+}
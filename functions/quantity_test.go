@@ -0,0 +1,145 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuantity(t *testing.T) {
+	env := map[string]any{
+		"memory": "1.3G",
+		"cpu":    "250m",
+	}
+
+	tests := []struct {
+		name           string
+		exp            string
+		want           any
+		wantCompileErr bool
+		wantRuntimeErr bool
+	}{
+		{
+			name: "is quantity",
+			exp:  `isQuantity(memory) && isQuantity("500Mi") && isQuantity("1")`,
+			want: true,
+		},
+		{
+			name: "is not quantity",
+			exp:  `isQuantity("1.3 gigabytes")`,
+			want: false,
+		},
+		{
+			name: "fluent chain",
+			exp:  `quantity(memory).add(quantity("700M")).sub(1).isLessThan(quantity("2G"))`,
+			want: true,
+		},
+		{
+			name: "add quantities",
+			exp:  `quantity(memory).add(quantity("700M")).isEqualTo(quantity("2G"))`,
+			want: true,
+		},
+		{
+			name: "add a number",
+			exp:  `quantity("1Ki").add(1).asInteger()`,
+			want: 1025,
+		},
+		{
+			name: "sub a float",
+			exp:  `quantity("1").sub(0.5).isEqualTo(quantity("500m"))`,
+			want: true,
+		},
+		{
+			name: "binary and decimal suffixes compare by value",
+			exp:  `quantity("1Gi").isGreaterThan(quantity("1G"))`,
+			want: true,
+		},
+		{
+			name: "milli quantities",
+			exp:  `quantity(cpu).add(quantity("750m")).isEqualTo(1)`,
+			want: true,
+		},
+		{
+			name: "as integer",
+			exp:  `quantity(memory).asInteger()`,
+			want: 1300000000,
+		},
+		{
+			name: "arithmetic does not modify the receiver",
+			exp:  `let q = quantity("1"); q.add(1).isEqualTo(2) && q.isEqualTo(1)`,
+			want: true,
+		},
+		{
+			name:           "invalid quantity",
+			exp:            `quantity("1.3 gigabytes")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "fractional as integer",
+			exp:            `quantity(cpu).asInteger()`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "add a string",
+			exp:            `quantity("1").add("2")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "unknown method",
+			exp:            `quantity(memory).multiply(2)`,
+			wantCompileErr: true,
+		},
+		{
+			name:           "comparison result is typed",
+			exp:            `quantity(memory).isLessThan(1) + 1`,
+			wantCompileErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Quantity())
+			if tc.wantCompileErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParsedQuantityMarshalJSON(t *testing.T) {
+	program, err := expr.Compile(`quantity("1.3G").add(quantity("700M"))`, expr.Env(nil), Quantity())
+	require.NoError(t, err)
+	got, err := expr.Run(program, nil)
+	require.NoError(t, err)
+
+	out, err := json.Marshal(got)
+	require.NoError(t, err)
+	assert.Equal(t, `"2G"`, string(out))
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/expr-lang/expr"
+)
+
+// Quoting provides the quote and unquote functions as Expr functions. quote returns a double-quoted Go string literal
+// with control characters and quotes escaped. unquote is the inverse and interprets a quoted Go string literal,
+// returning an error if the input is not validly quoted.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Quoting())
+//
+// Expression:
+//
+//	quote('say "hi"')         // `"say \"hi\""`
+//	unquote('"say \\"hi\\""') // `say "hi"`
+func Quoting() expr.Option {
+	return options(
+		expr.Function("quote", func(params ...any) (any, error) {
+			if len(params) != 1 {
+				return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+			}
+			s, err := arg[string](params, 0)
+			if err != nil {
+				return nil, err
+			}
+			return strconv.Quote(s), nil
+		},
+			new(func(string) string),
+		),
+		expr.Function("unquote", func(params ...any) (any, error) {
+			if len(params) != 1 {
+				return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+			}
+			s, err := arg[string](params, 0)
+			if err != nil {
+				return nil, err
+			}
+			out, err := strconv.Unquote(s)
+			if err != nil {
+				return nil, fmt.Errorf("unable to unquote %s: %w", s, err)
+			}
+			return out, nil
+		},
+			new(func(string) (string, error)),
+		),
+	)
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoting(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "quote",
+			exp:  `quote(s)`,
+			want: `"say \"hi\"\nbye"`,
+		},
+		{
+			name: "round trip",
+			exp:  `unquote(quote(s)) == s`,
+			want: true,
+		},
+		{
+			name: "unquote",
+			exp:  `unquote(quoted)`,
+			want: "say \"hi\"\nbye",
+		},
+		{
+			name: "unquote raw string",
+			exp:  "unquote('`raw\\\\n`')",
+			want: `raw\n`,
+		},
+		{
+			name:           "unquote unterminated",
+			exp:            `unquote('"abc')`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "unquote unquoted",
+			exp:            `unquote('abc')`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{
+		"s":      "say \"hi\"\nbye",
+		"quoted": `"say \"hi\"\nbye"`,
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Quoting())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// random is the source of randomness shared by the functions that make random choices, such as shuffle. It is
+// seeded from the clock, and tests reseed it with seedRandom so that results are reproducible.
+var random = struct {
+	sync.Mutex
+	r *rand.Rand
+}{r: rand.New(rand.NewSource(time.Now().UnixNano()))}
+
+// withRandom calls f with the shared *rand.Rand. A *rand.Rand is not safe for concurrent use, so access is serialized.
+func withRandom(f func(r *rand.Rand)) {
+	random.Lock()
+	defer random.Unlock()
+	f(random.r)
+}
+
+// seedRandom replaces the shared source with one seeded by seed.
+func seedRandom(seed int64) {
+	random.Lock()
+	defer random.Unlock()
+	random.r = rand.New(rand.NewSource(seed))
+}
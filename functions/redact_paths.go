@@ -0,0 +1,120 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/expr-lang/expr"
+)
+
+// redactedValue replaces the values redacted by redactPaths.
+const redactedValue = "***"
+
+// RedactPaths provides the redactPaths function as an Expr function. It returns a copy of a map with the values at
+// each of a list of dotted paths replaced by "***". As with flattenKeys, list elements are addressed by their index.
+// Paths that do not lead to a value are ignored. The map itself is not modified; the copy is deep, and lists in it
+// are returned as lists of any.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.RedactPaths())
+//
+// Expression:
+//
+//	redactPaths({"auth": {"token": "abc"}}, ["auth.token"]) // {"auth": {"token": "***"}}
+//	redactPaths({"keys": ["a", "b"]}, ["keys.1", "missing"]) // {"keys": ["a", "***"]}
+func RedactPaths() expr.Option {
+	return expr.Function("redactPaths", func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		m, err := arg[map[string]any](params, 0)
+		if err != nil {
+			return nil, err
+		}
+		paths, err := toList(params[1])
+		if err != nil {
+			return nil, err
+		}
+		out := deepCopy(m).(map[string]any)
+		for i, p := range paths {
+			path, ok := p.(string)
+			if !ok {
+				return nil, fmt.Errorf("element %d: expected a string path, got %T", i, p)
+			}
+			redactPath(out, strings.Split(path, "."))
+		}
+		return out, nil
+	},
+		new(func(map[string]any, []any) (map[string]any, error)),
+		new(func(map[string]any, []string) (map[string]any, error)),
+	)
+}
+
+// deepCopy returns a copy of v in which every map[string]any is copied and every list is copied into a []any. Other
+// values are shared with v.
+func deepCopy(v any) any {
+	if m, ok := v.(map[string]any); ok {
+		out := make(map[string]any, len(m))
+		for k, mv := range m {
+			out[k] = deepCopy(mv)
+		}
+		return out
+	}
+	if l, err := toList(v); err == nil {
+		out := make([]any, len(l))
+		for i, lv := range l {
+			out[i] = deepCopy(lv)
+		}
+		return out
+	}
+	return v
+}
+
+// redactPath replaces the value at the path given by segments in v, a copy made by deepCopy, with redactedValue. It
+// does nothing if the path does not lead to a value.
+func redactPath(v any, segments []string) {
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		switch c := v.(type) {
+		case map[string]any:
+			next, ok := c[segment]
+			if !ok {
+				return
+			}
+			if last {
+				c[segment] = redactedValue
+				return
+			}
+			v = next
+		case []any:
+			n, err := strconv.Atoi(segment)
+			if err != nil || n < 0 || n >= len(c) {
+				return
+			}
+			if last {
+				c[n] = redactedValue
+				return
+			}
+			v = c[n]
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactPaths(t *testing.T) {
+	newEnv := func() map[string]any {
+		return map[string]any{
+			"config": map[string]any{
+				"name": "api",
+				"database": map[string]any{
+					"user":     "admin",
+					"password": "hunter2",
+				},
+				"tokens": []string{"abc", "def"},
+			},
+			"paths": []string{"database.password"},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		exp     string
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			name: "nested secret",
+			exp:  `redactPaths(config, ["database.password"])`,
+			want: map[string]any{
+				"name": "api",
+				"database": map[string]any{
+					"user":     "admin",
+					"password": "***",
+				},
+				"tokens": []any{"abc", "def"},
+			},
+		},
+		{
+			name: "typed list of paths",
+			exp:  `redactPaths(config, paths)`,
+			want: map[string]any{
+				"name": "api",
+				"database": map[string]any{
+					"user":     "admin",
+					"password": "***",
+				},
+				"tokens": []any{"abc", "def"},
+			},
+		},
+		{
+			name: "list element and whole subtree",
+			exp:  `redactPaths(config, ["tokens.1", "database"])`,
+			want: map[string]any{
+				"name":     "api",
+				"database": "***",
+				"tokens":   []any{"abc", "***"},
+			},
+		},
+		{
+			name: "paths that do not resolve are ignored",
+			exp:  `redactPaths(config, ["database.host", "tokens.2", "name.first", "missing"])`,
+			want: map[string]any{
+				"name": "api",
+				"database": map[string]any{
+					"user":     "admin",
+					"password": "hunter2",
+				},
+				"tokens": []any{"abc", "def"},
+			},
+		},
+		{
+			name:    "path that is not a string",
+			exp:     `redactPaths(config, [1])`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			env := newEnv()
+			program, err := expr.Compile(tc.exp, expr.Env(env), RedactPaths())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, newEnv(), env, "the input was modified")
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/expr-lang/expr"
+)
+
+// RedactRegex provides the redactRegex function as an Expr function. It replaces every match of a regular expression
+// with a mask of "*" characters the same length as the match, or with the given replacement when a third argument is
+// provided. This is useful for scrubbing emails, tokens, or other sensitive values from log lines.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.RedactRegex())
+//
+// Expression:
+//
+//	redactRegex("pin: 1234", "[0-9]+")                // "pin: ****"
+//	redactRegex("pin: 1234", "[0-9]+", "[REDACTED]") // "pin: [REDACTED]"
+func RedactRegex() expr.Option {
+	return expr.Function("redactRegex", func(params ...any) (any, error) {
+		if len(params) < 2 || len(params) > 3 {
+			return nil, fmt.Errorf("expected two or three parameters, got %d", len(params))
+		}
+		s, err := arg[string](params, 0)
+		if err != nil {
+			return nil, err
+		}
+		pattern, err := arg[string](params, 1)
+		if err != nil {
+			return nil, err
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		if len(params) == 3 {
+			replacement, err := arg[string](params, 2)
+			if err != nil {
+				return nil, err
+			}
+			return re.ReplaceAllLiteralString(s, replacement), nil
+		}
+		return re.ReplaceAllStringFunc(s, func(m string) string {
+			return strings.Repeat("*", utf8.RuneCountInString(m))
+		}), nil
+	},
+		new(func(string, string) (string, error)),
+		new(func(string, string, string) (string, error)),
+	)
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactRegex(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           string
+		wantRuntimeErr bool
+	}{
+		{
+			name: "mask email addresses",
+			exp:  `redactRegex("from bob@example.com to al@test.io", "[a-z]+@[a-z.]+")`,
+			want: "from *************** to **********",
+		},
+		{
+			name: "mask preserves multibyte length",
+			exp:  `redactRegex("name=José;", "José")`,
+			want: "name=****;",
+		},
+		{
+			name: "custom replacement token",
+			exp:  `redactRegex("from bob@example.com to al@test.io", "[a-z]+@[a-z.]+", "<email>")`,
+			want: "from <email> to <email>",
+		},
+		{
+			name: "replacement is literal",
+			exp:  `redactRegex("key=abc", "(abc)", "$1")`,
+			want: "key=$1",
+		},
+		{
+			name: "no match",
+			exp:  `redactRegex("nothing here", "[0-9]+")`,
+			want: "nothing here",
+		},
+		{
+			name:           "invalid pattern",
+			exp:            `redactRegex("abc", "(")`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(nil), RedactRegex())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, nil)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
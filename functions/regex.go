@@ -0,0 +1,145 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+)
+
+// regexFindName is the name the find function is registered under. Registering it as find would shadow the find
+// builtin, which takes a predicate, so regexPatch rewrites calls to find with a pattern to call this name instead.
+const regexFindName = "regex.find"
+
+// Regex provides the find and findAll functions as Expr functions. find returns the first match of a regular
+// expression in a string, or an empty string when there is no match. findAll returns every match. Both can be called
+// as functions, with the pipe operator, or as methods on a string. Invalid patterns are a runtime error.
+//
+// Expr already has a find builtin that returns the first element of a list matching a predicate. A call to find is
+// treated as a regular expression search when either its first argument or its pattern is known to be a string;
+// otherwise it is left to the builtin.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Regex())
+//
+// Expression:
+//
+//	find("v1.25.3", "[0-9]+")   // "1"
+//	"v1.25.3".findAll("[0-9]+") // ["1", "25", "3"]
+//	"v1.25.3" | find("^v[0-9]") // "v1"
+func Regex() expr.Option {
+	return options(
+		expr.Function(regexFindName, func(params ...any) (any, error) {
+			re, s, err := regexParams(params)
+			if err != nil {
+				return nil, err
+			}
+			return re.FindString(s), nil
+		},
+			new(func(string, string) (string, error)),
+		),
+		expr.Function("findAll", func(params ...any) (any, error) {
+			re, s, err := regexParams(params)
+			if err != nil {
+				return nil, err
+			}
+			out := make([]any, 0)
+			for _, m := range re.FindAllString(s, -1) {
+				out = append(out, m)
+			}
+			return out, nil
+		},
+			new(func(string, string) ([]any, error)),
+		),
+		expr.Patch(regexPatch{}),
+	)
+}
+
+// regexParams returns the compiled pattern and the string to search from the parameters of find and findAll.
+func regexParams(params []any) (*regexp.Regexp, string, error) {
+	if len(params) != 2 {
+		return nil, "", fmt.Errorf("expected two parameters, got %d", len(params))
+	}
+	s, err := arg[string](params, 0)
+	if err != nil {
+		return nil, "", err
+	}
+	pattern, err := arg[string](params, 1)
+	if err != nil {
+		return nil, "", err
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return re, s, nil
+}
+
+// regexPatch rewrites the builtin find(s, pattern) when s or pattern is a string, and the method calls s.find(pattern)
+// and s.findAll(pattern) when s is a string or only known at runtime, to call the regular expression functions.
+type regexPatch struct{}
+
+func (regexPatch) Visit(node *ast.Node) {
+	switch n := (*node).(type) {
+	case *ast.BuiltinNode:
+		if n.Name != "find" || len(n.Arguments) != 2 {
+			return
+		}
+		closure, ok := n.Arguments[1].(*ast.ClosureNode)
+		if !ok || !isString(n.Arguments[0].Type()) && !isString(closure.Node.Type()) {
+			return
+		}
+		patchRegexCall(node, regexFindName, n.Arguments[0], closure.Node)
+	case *ast.CallNode:
+		member, ok := n.Callee.(*ast.MemberNode)
+		if !ok || member.Optional {
+			return
+		}
+		property, ok := member.Property.(*ast.StringNode)
+		if !ok {
+			return
+		}
+		if t := member.Node.Type(); t != nil && t.Kind() != reflect.Interface && !isString(t) {
+			return
+		}
+		switch {
+		case property.Value == "find" && len(n.Arguments) == 1:
+			patchRegexCall(node, regexFindName, member.Node, n.Arguments[0])
+		case property.Value == "findAll" && len(n.Arguments) == 1:
+			patchRegexCall(node, "findAll", member.Node, n.Arguments[0])
+		}
+	}
+}
+
+// patchRegexCall replaces node with a call to the function name with the arguments s and pattern.
+func patchRegexCall(node *ast.Node, name string, s, pattern ast.Node) {
+	callee := &ast.IdentifierNode{Value: name}
+	callee.SetLocation((*node).Location())
+	ast.Patch(node, &ast.CallNode{
+		Callee:    callee,
+		Arguments: []ast.Node{s, pattern},
+	})
+}
+
+// isString reports whether t is a string type.
+func isString(t reflect.Type) bool {
+	return t != nil && t.Kind() == reflect.String
+}
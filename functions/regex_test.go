@@ -0,0 +1,73 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegex(t *testing.T) {
+	env := map[string]any{
+		"image":   "nginx:v1.25.3",
+		"object":  map[string]any{"image": "nginx:v1.25.3"},
+		"pattern": "[0-9]+",
+		"items":   []int{1, 2, 3},
+	}
+
+	tests := []struct {
+		name    string
+		exp     string
+		want    any
+		wantErr bool
+	}{
+		{name: "find", exp: `find(image, "[0-9]+")`, want: "1"},
+		{name: "find with a pattern variable", exp: `find(object.image, pattern)`, want: "1"},
+		{name: "find returns the whole match of a pattern with capture groups", exp: `find(image, "v([0-9]+)\\.([0-9]+)")`, want: "v1.25"},
+		{name: "find anchored at the end", exp: `find(image, "[0-9]+$")`, want: "3"},
+		{name: "find anchored at the start", exp: `find(image, "^[0-9]+")`, want: ""},
+		{name: "find no match", exp: `find(image, "alpine")`, want: ""},
+		{name: "find as a method", exp: `object.image.find("v[0-9]+.[0-9]+.[0-9]*$")`, want: "v1.25.3"},
+		{name: "find with the pipe operator", exp: `object.image | find("^[a-z]+")`, want: "nginx"},
+		{name: "find on a list still uses the builtin", exp: `find(items, # > 1)`, want: 2},
+		{name: "find on a list from a pipe still uses the builtin", exp: `items | find(# > 2)`, want: 3},
+		{name: "findAll", exp: `findAll(image, "[0-9]+")`, want: []any{"1", "25", "3"}},
+		{name: "findAll with capture groups", exp: `findAll("a=1, b=2", "([a-z])=([0-9])")`, want: []any{"a=1", "b=2"}},
+		{name: "findAll as a method", exp: `object.image.findAll("[0-9]+")`, want: []any{"1", "25", "3"}},
+		{name: "findAll with the pipe operator", exp: `image | findAll("[0-9]+$")`, want: []any{"3"}},
+		{name: "findAll no match", exp: `findAll(image, "alpine")`, want: []any{}},
+		{name: "invalid pattern", exp: `find(image, "[0-9")`, wantErr: true},
+		{name: "invalid pattern as a method", exp: `object.image.findAll("(")`, wantErr: true},
+		{name: "method on a value that is not a string", exp: `object.find("[0-9]+")`, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Regex())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
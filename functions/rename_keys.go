@@ -0,0 +1,78 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/expr-lang/expr"
+)
+
+// RenameKeys provides the renameKeys function as an Expr function. It returns a copy of a map where each key found in
+// mapping is renamed to the string it maps to, and all other keys are kept as they are. It is an error for two keys
+// of the result to collide, whether both were renamed to the same target or one was renamed onto a key that was kept.
+// The input map is not modified.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.RenameKeys())
+//
+// Expression:
+//
+//	renameKeys({"user_name": "a", "id": 1}, {"user_name": "userName"}) // {"userName": "a", "id": 1}
+func RenameKeys() expr.Option {
+	return expr.Function("renameKeys", func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		m, err := arg[map[string]any](params, 0)
+		if err != nil {
+			return nil, err
+		}
+		mapping, err := arg[map[string]any](params, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		out := make(map[string]any, len(m))
+		from := make(map[string]string, len(m))
+		for _, k := range keys {
+			target := k
+			if to, ok := mapping[k]; ok {
+				s, ok := to.(string)
+				if !ok {
+					return nil, fmt.Errorf("mapping for %q: expected a string, got %T", k, to)
+				}
+				target = s
+			}
+			if prev, ok := from[target]; ok {
+				return nil, fmt.Errorf("keys %q and %q both map to %q", prev, k, target)
+			}
+			from[target] = k
+			out[target] = m[k]
+		}
+		return out, nil
+	},
+		new(func(map[string]any, map[string]any) (map[string]any, error)),
+	)
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameKeys(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "rename with an untouched key",
+			exp:  `renameKeys({"user_name": "alice", "id": 1}, {"user_name": "userName"})`,
+			want: map[string]any{"userName": "alice", "id": 1},
+		},
+		{
+			name: "mapping for an absent key is ignored",
+			exp:  `renameKeys({"id": 1}, {"name": "title"})`,
+			want: map[string]any{"id": 1},
+		},
+		{
+			name: "swap keys",
+			exp:  `renameKeys({"a": 1, "b": 2}, {"a": "b", "b": "a"})`,
+			want: map[string]any{"a": 2, "b": 1},
+		},
+		{
+			name: "input is not modified",
+			exp:  `renameKeys(user, {"name": "login"}) != user && user.name == "bob"`,
+			want: true,
+		},
+		{
+			name:           "two keys renamed to the same target",
+			exp:            `renameKeys({"a": 1, "b": 2}, {"a": "c", "b": "c"})`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "renamed onto a kept key",
+			exp:            `renameKeys({"a": 1, "b": 2}, {"a": "b"})`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "non-string target",
+			exp:            `renameKeys({"a": 1}, {"a": 2})`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{"user": map[string]any{"name": "bob"}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), RenameKeys())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
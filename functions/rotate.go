@@ -0,0 +1,66 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// Rotate provides the rotate function as an Expr function. It returns a new list rotated left by n positions, or
+// right when n is negative. Rotations wrap around, so rotating by the length of the list returns it unchanged.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Rotate())
+//
+// Expression:
+//
+//	rotate([1, 2, 3, 4], 1)  // [2, 3, 4, 1]
+//	rotate([1, 2, 3, 4], -1) // [4, 1, 2, 3]
+func Rotate() expr.Option {
+	return expr.Function("rotate", func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		list, err := toList(params[0])
+		if err != nil {
+			return nil, err
+		}
+		n, err := arg[int](params, 1)
+		if err != nil {
+			return nil, err
+		}
+		return rotate(list, n), nil
+	},
+		new(func([]any, int) ([]any, error)),
+		new(func([]int, int) ([]any, error)),
+		new(func([]float64, int) ([]any, error)),
+		new(func([]string, int) ([]any, error)),
+	)
+}
+
+// rotate returns a copy of list rotated left by n positions.
+func rotate(list []any, n int) []any {
+	out := make([]any, 0, len(list))
+	if len(list) == 0 {
+		return out
+	}
+	n = (n%len(list) + len(list)) % len(list)
+	out = append(out, list[n:]...)
+	return append(out, list[:n]...)
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want []any
+	}{
+		{
+			name: "rotate left",
+			exp:  `rotate([1, 2, 3, 4], 1)`,
+			want: []any{2, 3, 4, 1},
+		},
+		{
+			name: "rotate right",
+			exp:  `rotate([1, 2, 3, 4], -1)`,
+			want: []any{4, 1, 2, 3},
+		},
+		{
+			name: "greater than length wraps",
+			exp:  `rotate([1, 2, 3, 4], 6)`,
+			want: []any{3, 4, 1, 2},
+		},
+		{
+			name: "negative greater than length wraps",
+			exp:  `rotate([1, 2, 3, 4], -5)`,
+			want: []any{4, 1, 2, 3},
+		},
+		{
+			name: "full rotation",
+			exp:  `rotate([1, 2, 3], 3)`,
+			want: []any{1, 2, 3},
+		},
+		{
+			name: "typed list from the environment",
+			exp:  `rotate(hosts, 2)`,
+			want: []any{"c", "a", "b"},
+		},
+		{
+			name: "empty list",
+			exp:  `rotate([], 3)`,
+			want: []any{},
+		},
+	}
+
+	env := map[string]any{"hosts": []string{"a", "b", "c"}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Rotate())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
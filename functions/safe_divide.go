@@ -0,0 +1,64 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// SafeDivide provides the safeDivide function as an Expr function. It divides a by b, returning the fallback instead
+// of failing when b is zero. The fallback defaults to 0. This keeps ratio based policies from failing when a
+// denominator is missing or zero. NaN and infinite parameters are an error, as is a quotient too large for a float.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.SafeDivide())
+//
+// Expression:
+//
+//	safeDivide(10, 4)     // 2.5
+//	safeDivide(10, 0)     // 0.0
+//	safeDivide(10, 0, -1) // -1.0
+func SafeDivide() expr.Option {
+	return expr.Function("safeDivide", func(params ...any) (any, error) {
+		if len(params) < 2 || len(params) > 3 {
+			return nil, fmt.Errorf("expected two or three parameters, got %d", len(params))
+		}
+		a, err := argFiniteFloat(params, 0)
+		if err != nil {
+			return nil, err
+		}
+		b, err := argFiniteFloat(params, 1)
+		if err != nil {
+			return nil, err
+		}
+		var fallback float64
+		if len(params) == 3 {
+			if fallback, err = argFiniteFloat(params, 2); err != nil {
+				return nil, err
+			}
+		}
+		if b == 0 {
+			return fallback, nil
+		}
+		return finite("safeDivide", a/b)
+	},
+		new(func(float64, float64) (float64, error)),
+		new(func(float64, float64, float64) (float64, error)),
+	)
+}
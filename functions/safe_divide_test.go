@@ -0,0 +1,94 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafeDivide(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           float64
+		wantRuntimeErr bool
+	}{
+		{
+			name: "normal division",
+			exp:  `safeDivide(10, 4)`,
+			want: 2.5,
+		},
+		{
+			name: "normal division with fallback",
+			exp:  `safeDivide(errors, requests, 1)`,
+			want: 0.05,
+		},
+		{
+			name: "zero divisor with fallback",
+			exp:  `safeDivide(errors, zero, -1)`,
+			want: -1,
+		},
+		{
+			name: "zero divisor defaults to zero",
+			exp:  `safeDivide(10, 0)`,
+			want: 0,
+		},
+		{
+			name: "zero float divisor",
+			exp:  `safeDivide(10, 0.0, 1.5)`,
+			want: 1.5,
+		},
+		{
+			name:           "NaN dividend",
+			exp:            `safeDivide(0 / 0, 2)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "infinite divisor",
+			exp:            `safeDivide(1, 1 / 0)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "infinite fallback",
+			exp:            `safeDivide(1, 0, 1 / 0)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "quotient overflows",
+			exp:            `safeDivide(1e308, 1e-308)`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{"errors": 5, "requests": 100, "zero": 0}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), SafeDivide())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/expr-lang/expr"
+)
+
+// Sample provides the sample and sampleOne functions as Expr functions. sample returns n distinct elements chosen at
+// random from a list, without replacement, and sampleOne returns a single random element. Asking for more elements
+// than the list holds, or sampling from an empty list, is an error.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Sample())
+//
+// Expression:
+//
+//	sample([1, 2, 3, 4], 2) // e.g. [3, 1]
+//	sampleOne(["a", "b"])   // e.g. "b"
+func Sample() expr.Option {
+	return options(
+		expr.Function("sample", func(params ...any) (any, error) {
+			if len(params) != 2 {
+				return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+			}
+			list, err := toList(params[0])
+			if err != nil {
+				return nil, err
+			}
+			n, err := arg[int](params, 1)
+			if err != nil {
+				return nil, err
+			}
+			return sample(list, n)
+		},
+			new(func([]any, int) ([]any, error)),
+			new(func([]int, int) ([]any, error)),
+			new(func([]float64, int) ([]any, error)),
+			new(func([]string, int) ([]any, error)),
+		),
+		expr.Function("sampleOne", func(params ...any) (any, error) {
+			if len(params) != 1 {
+				return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+			}
+			list, err := toList(params[0])
+			if err != nil {
+				return nil, err
+			}
+			if len(list) == 0 {
+				return nil, fmt.Errorf("expected a non-empty list")
+			}
+			out, err := sample(list, 1)
+			if err != nil {
+				return nil, err
+			}
+			return out[0], nil
+		},
+			new(func([]any) (any, error)),
+			new(func([]int) (any, error)),
+			new(func([]float64) (any, error)),
+			new(func([]string) (any, error)),
+		),
+	)
+}
+
+// sample returns n distinct elements of list in random order.
+func sample(list []any, n int) ([]any, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("sample size must not be negative, got %d", n)
+	}
+	if n > len(list) {
+		return nil, fmt.Errorf("cannot sample %d elements from a list of %d", n, len(list))
+	}
+	var perm []int
+	withRandom(func(r *rand.Rand) {
+		perm = r.Perm(len(list))
+	})
+	out := make([]any, n)
+	for i := range out {
+		out[i] = list[perm[i]]
+	}
+	return out, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSample(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           any
+		wantRuntimeErr bool
+		wantErrMsg     string
+	}{
+		{
+			name: "seeded sample",
+			exp:  `sample([1, 2, 3, 4, 5, 6], 3)`,
+			want: []any{1, 6, 4},
+		},
+		{
+			name: "seeded sampleOne",
+			exp:  `sampleOne(["a", "b", "c", "d"])`,
+			want: "a",
+		},
+		{
+			name: "whole list is a permutation",
+			exp:  `sort(sample([3, 1, 2], 3))`,
+			want: []any{1, 2, 3},
+		},
+		{
+			name: "zero elements",
+			exp:  `sample([1, 2], 0)`,
+			want: []any{},
+		},
+		{
+			name:           "more than the list length",
+			exp:            `sample([1, 2], 3)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "negative size",
+			exp:            `sample([1, 2], -1)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "sampleOne from an empty list",
+			exp:            `sampleOne([])`,
+			wantRuntimeErr: true,
+			wantErrMsg:     "expected a non-empty list",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			seedRandom(42)
+			program, err := expr.Compile(tc.exp, expr.Env(nil), Sample())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, nil)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				require.ErrorContains(t, err, tc.wantErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
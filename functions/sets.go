@@ -0,0 +1,140 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+)
+
+// setSignatures are the signatures of the sets functions, which accept any pairing of list types.
+var setSignatures = []any{
+	new(func([]any, []any) (bool, error)),
+	new(func([]any, []int) (bool, error)),
+	new(func([]any, []float64) (bool, error)),
+	new(func([]any, []string) (bool, error)),
+	new(func([]int, []any) (bool, error)),
+	new(func([]int, []int) (bool, error)),
+	new(func([]float64, []any) (bool, error)),
+	new(func([]float64, []float64) (bool, error)),
+	new(func([]string, []any) (bool, error)),
+	new(func([]string, []string) (bool, error)),
+}
+
+// Sets provides the sets.contains, sets.equivalent, and sets.intersects functions of the Kubernetes CEL sets library
+// as Expr functions. Each treats its two lists as sets, ignoring order and duplicates, and compares elements the way
+// == does, so nested lists and maps are compared by value.
+//
+//   - sets.contains(a, b) reports whether every element of b is in a.
+//   - sets.equivalent(a, b) reports whether a and b hold the same elements.
+//   - sets.intersects(a, b) reports whether a and b have at least one element in common.
+//
+// Expr does not allow dots in function names, so this option also patches calls written as sets.name(...) to call
+// the function registered under that name. As a result, the calls take precedence over a variable named sets.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Sets())
+//
+// Expression:
+//
+//	sets.contains([1, 2, 3, 4], [2, 3])              // true
+//	sets.equivalent([1], [1, 1])                     // true
+//	sets.intersects([[1], [2, 3]], [[1, 2], [2, 3]]) // true
+func Sets() expr.Option {
+	return options(
+		setFunction("contains", containsAll),
+		setFunction("equivalent", func(a, b []any) bool {
+			return containsAll(a, b) && containsAll(b, a)
+		}),
+		setFunction("intersects", func(a, b []any) bool {
+			for _, v := range b {
+				if containsElement(a, v) {
+					return true
+				}
+			}
+			return false
+		}),
+		expr.Patch(setsPatch{}),
+	)
+}
+
+// setFunction returns an Expr function named sets.name that applies f to its two list parameters.
+func setFunction(name string, f func(a, b []any) bool) expr.Option {
+	return expr.Function("sets."+name, func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		a, err := toList(params[0])
+		if err != nil {
+			return nil, err
+		}
+		b, err := toList(params[1])
+		if err != nil {
+			return nil, err
+		}
+		return f(a, b), nil
+	},
+		setSignatures...,
+	)
+}
+
+// containsAll reports whether every element of b is in a.
+func containsAll(a, b []any) bool {
+	for _, v := range b {
+		if !containsElement(a, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsElement reports whether v is equal to an element of list.
+func containsElement(list []any, v any) bool {
+	for _, e := range list {
+		if equal(e, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// setsPatch rewrites calls of the form sets.name(...) into calls of the function named "sets.name".
+type setsPatch struct{}
+
+func (setsPatch) Visit(node *ast.Node) {
+	call, ok := (*node).(*ast.CallNode)
+	if !ok {
+		return
+	}
+	member, ok := call.Callee.(*ast.MemberNode)
+	if !ok || member.Optional {
+		return
+	}
+	id, ok := member.Node.(*ast.IdentifierNode)
+	if !ok || id.Value != "sets" {
+		return
+	}
+	name, ok := member.Property.(*ast.StringNode)
+	if !ok {
+		return
+	}
+	callee := &ast.IdentifierNode{Value: "sets." + name.Value}
+	callee.SetLocation(member.Location())
+	call.Callee = callee
+}
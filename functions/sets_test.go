@@ -0,0 +1,74 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSets(t *testing.T) {
+	env := map[string]any{
+		"ints":    []int{1, 2, 3},
+		"strings": []string{"a", "b"},
+	}
+
+	tests := []struct {
+		name           string
+		exp            string
+		want           bool
+		wantCompileErr bool
+	}{
+		{name: "contains empty in empty", exp: `sets.contains([], [])`, want: true},
+		{name: "contains non-empty in empty", exp: `sets.contains([], [1])`, want: false},
+		{name: "contains subset", exp: `sets.contains([1, 2, 3, 4], [2, 3])`, want: true},
+		{name: "contains ignores order", exp: `sets.contains([1, 2, 3], [3, 2, 1])`, want: true},
+		{name: "contains ignores duplicates", exp: `sets.contains([1], [1, 1, 1])`, want: true},
+		{name: "contains missing element", exp: `sets.contains([1, 2], [2, 3])`, want: false},
+		{name: "contains compares numbers by value", exp: `sets.contains([1, 2.0], [1.0, 2])`, want: true},
+		{name: "contains nested lists", exp: `sets.contains([[1, 2], [3]], [[3]])`, want: true},
+		{name: "contains maps", exp: `sets.contains([{"a": 1}, {"b": 2}], [{"b": 2}])`, want: true},
+		{name: "equivalent empty", exp: `sets.equivalent([], [])`, want: true},
+		{name: "equivalent duplicates", exp: `sets.equivalent([1], [1, 1])`, want: true},
+		{name: "equivalent order", exp: `sets.equivalent([1, 2, 3], [3, 2, 1])`, want: true},
+		{name: "not equivalent", exp: `sets.equivalent([1, 2], [1])`, want: false},
+		{name: "intersects empty", exp: `sets.intersects([1], [])`, want: false},
+		{name: "intersects", exp: `sets.intersects([1], [1, 2])`, want: true},
+		{name: "intersects nested lists", exp: `sets.intersects([[1], [2, 3]], [[1, 2], [2, 3]])`, want: true},
+		{name: "disjoint nested lists", exp: `sets.intersects([[1], [2]], [[1, 2]])`, want: false},
+		{name: "typed lists from the environment", exp: `sets.contains(ints, [3, 1]) && sets.intersects(strings, ["b", "c"])`, want: true},
+		{name: "wrong number of arguments", exp: `sets.contains([1])`, wantCompileErr: true},
+		{name: "unknown sets function", exp: `sets.union([1], [2])`, wantCompileErr: true},
+		{name: "non-list argument", exp: `sets.contains([1], 1)`, wantCompileErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Sets())
+			if tc.wantCompileErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/expr-lang/expr"
+)
+
+// Shuffle provides the shuffle function as an Expr function. It returns a randomly permuted copy of a list, leaving
+// the original unchanged.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Shuffle())
+//
+// Expression:
+//
+//	shuffle([1, 2, 3, 4]) // e.g. [3, 1, 4, 2]
+func Shuffle() expr.Option {
+	return expr.Function("shuffle", func(params ...any) (any, error) {
+		if len(params) != 1 {
+			return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+		}
+		list, err := toList(params[0])
+		if err != nil {
+			return nil, err
+		}
+		out := append([]any{}, list...)
+		withRandom(func(r *rand.Rand) {
+			r.Shuffle(len(out), func(i, j int) { out[i], out[j] = out[j], out[i] })
+		})
+		return out, nil
+	},
+		new(func([]any) ([]any, error)),
+		new(func([]int) ([]any, error)),
+		new(func([]float64) ([]any, error)),
+		new(func([]string) ([]any, error)),
+	)
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShuffle(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want []any
+	}{
+		{
+			name: "seeded permutation",
+			exp:  `shuffle([1, 2, 3, 4, 5, 6])`,
+			want: []any{2, 4, 5, 6, 1, 3},
+		},
+		{
+			name: "single element",
+			exp:  `shuffle(["a"])`,
+			want: []any{"a"},
+		},
+		{
+			name: "empty list",
+			exp:  `shuffle([])`,
+			want: []any{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			seedRandom(42)
+			program, err := expr.Compile(tc.exp, expr.Env(nil), Shuffle())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestShuffleInputUnchanged(t *testing.T) {
+	items := []any{1, 2, 3, 4, 5, 6, 7, 8}
+	env := map[string]any{"items": items}
+
+	program, err := expr.Compile(`shuffle(items)`, expr.Env(env), Shuffle())
+	require.NoError(t, err)
+
+	got, err := expr.Run(program, env)
+	require.NoError(t, err)
+	assert.Equal(t, []any{1, 2, 3, 4, 5, 6, 7, 8}, items)
+	assert.ElementsMatch(t, items, got)
+}
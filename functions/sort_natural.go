@@ -0,0 +1,73 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/expr-lang/expr"
+)
+
+// SortNatural provides the sortNatural function as an Expr function. It returns a copy of a list of strings sorted
+// using natural ordering (see NaturalCompare), so "v10" sorts after "v9". The input list is not modified.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.SortNatural())
+//
+// Expression:
+//
+//	sortNatural(["v9", "v10", "v2"]) // ["v2", "v9", "v10"]
+func SortNatural() expr.Option {
+	return expr.Function("sortNatural", func(params ...any) (any, error) {
+		if len(params) != 1 {
+			return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+		}
+		return sortNatural(params[0])
+	},
+		new(func([]any) ([]any, error)),
+		new(func([]string) ([]any, error)),
+	)
+}
+
+// sortNatural copies v into a new []any and sorts it by natural ordering. Every element must be a string.
+func sortNatural(v any) ([]any, error) {
+	var ss []string
+	switch t := v.(type) {
+	case []string:
+		ss = slices.Clone(t)
+	case []any:
+		ss = make([]string, len(t))
+		for i, e := range t {
+			s, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("unsupported element type %T at index %d, expected string", e, i)
+			}
+			ss[i] = s
+		}
+	default:
+		return nil, fmt.Errorf("type %s is not a list of strings", reflect.TypeOf(v))
+	}
+
+	slices.SortStableFunc(ss, naturalCompare)
+	out := make([]any, len(ss))
+	for i, s := range ss {
+		out[i] = s
+	}
+	return out, nil
+}
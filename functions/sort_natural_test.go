@@ -0,0 +1,72 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortNatural(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           []any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "version tags",
+			exp:  `sortNatural(["v9", "v10", "v2"])`,
+			want: []any{"v2", "v9", "v10"},
+		},
+		{
+			name: "string slice",
+			exp:  `sortNatural(tags)`,
+			want: []any{"1.2", "1.9", "1.10", "1.10.1"},
+		},
+		{
+			name: "empty",
+			exp:  `sortNatural([])`,
+			want: []any{},
+		},
+		{
+			name:           "non-string element",
+			exp:            `sortNatural(["v1", 2])`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	tags := []string{"1.10", "1.9", "1.10.1", "1.2"}
+	env := map[string]any{"tags": tags}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), SortNatural())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+	// The input must not be sorted in place.
+	assert.Equal(t, []string{"1.10", "1.9", "1.10.1", "1.2"}, tags)
+}
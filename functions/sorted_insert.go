@@ -0,0 +1,84 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"github.com/expr-lang/expr"
+)
+
+// SortedInsert provides the sortedInsertIndex function as an Expr function. It returns the index at which an element
+// would be inserted into a sorted list to keep it sorted. When the list already holds equal elements, the leftmost
+// position is returned. Lists of numbers and lists of strings are supported, and mixing the two is an error.
+//
+// The list is searched with a binary search and is not checked for order, so it is the caller's responsibility to
+// pass a sorted list; isSorted can be used to check it. The result for an unsorted list is unspecified.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.SortedInsert())
+//
+// Expression:
+//
+//	sortedInsertIndex([1, 3, 5], 4)    // 2
+//	sortedInsertIndex(["a", "c"], "b") // 1
+func SortedInsert() expr.Option {
+	return expr.Function("sortedInsertIndex", func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		list, err := toList(params[0])
+		if err != nil {
+			return nil, err
+		}
+		i, _, err := binarySearch(list, params[1])
+		return i, err
+	},
+		new(func([]any, any) (int, error)),
+		new(func([]int, any) (int, error)),
+		new(func([]float64, any) (int, error)),
+		new(func([]string, any) (int, error)),
+	)
+}
+
+// compareOrdered compares two numbers or two strings, returning -1, 0, or 1. Integers are compared exactly, and any
+// other pair of numbers is compared as floats. Comparing a number with a string, or any other type, is an error.
+func compareOrdered(a, b any) (int, error) {
+	if x, ok := a.(string); ok {
+		y, ok := b.(string)
+		if !ok {
+			return 0, fmt.Errorf("cannot compare string with %T", b)
+		}
+		return strings.Compare(x, y), nil
+	}
+	if x, err := toInt(a); err == nil {
+		if y, err := toInt(b); err == nil {
+			return cmp.Compare(x, y), nil
+		}
+	}
+	x, err := toFloat(a)
+	if err != nil {
+		return 0, fmt.Errorf("cannot compare %T, expected a number or a string", a)
+	}
+	y, err := toFloat(b)
+	if err != nil {
+		return 0, fmt.Errorf("cannot compare %T with %T", a, b)
+	}
+	return cmp.Compare(x, y), nil
+}
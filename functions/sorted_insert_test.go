@@ -0,0 +1,108 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedInsert(t *testing.T) {
+	env := map[string]any{
+		"ports": []int{22, 80, 443},
+		"names": []string{"alice", "bob", "carol"},
+	}
+
+	tests := []struct {
+		name           string
+		exp            string
+		want           int
+		wantRuntimeErr bool
+	}{
+		{
+			name: "start",
+			exp:  `sortedInsertIndex([3, 5, 7], 1)`,
+			want: 0,
+		},
+		{
+			name: "middle",
+			exp:  `sortedInsertIndex([3, 5, 7], 6)`,
+			want: 2,
+		},
+		{
+			name: "end",
+			exp:  `sortedInsertIndex([3, 5, 7], 9)`,
+			want: 3,
+		},
+		{
+			name: "leftmost of equal elements",
+			exp:  `sortedInsertIndex([1, 2, 2, 2, 3], 2)`,
+			want: 1,
+		},
+		{
+			name: "mixed ints and floats",
+			exp:  `sortedInsertIndex([1, 2.5, 4], 3)`,
+			want: 2,
+		},
+		{
+			name: "strings",
+			exp:  `sortedInsertIndex(["a", "c", "e"], "d")`,
+			want: 2,
+		},
+		{
+			name: "typed ints from the environment",
+			exp:  `sortedInsertIndex(ports, 8080)`,
+			want: 3,
+		},
+		{
+			name: "typed strings from the environment",
+			exp:  `sortedInsertIndex(names, "brian")`,
+			want: 2,
+		},
+		{
+			name: "empty list",
+			exp:  `sortedInsertIndex([], 1)`,
+			want: 0,
+		},
+		{
+			name:           "string into numbers",
+			exp:            `sortedInsertIndex([1, 2, 3], "2")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "unsupported element",
+			exp:            `sortedInsertIndex([true, false], true)`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), SortedInsert())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
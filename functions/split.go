@@ -0,0 +1,96 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/expr-lang/expr"
+)
+
+// SplitVariants provides the splitN and splitAfter functions as Expr functions.
+//
+// splitN splits a string around each separator into at most n substrings, with the last substring holding the
+// unsplit remainder. A negative n returns all substrings and an n of zero returns an empty list.
+//
+// splitAfter splits a string after each separator, keeping the separator at the end of each substring. It accepts
+// the same optional n as splitN. It matches the Expr builtin of the same name, and is provided so it remains
+// available in environments that disable the builtins.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.SplitVariants())
+//
+// Expression:
+//
+//	splitN("a,b,c,d", ",", 2) // ["a", "b,c,d"]
+//	splitAfter("a,b,c", ",")  // ["a,", "b,", "c"]
+func SplitVariants() expr.Option {
+	return options(
+		expr.Function("splitN", func(params ...any) (any, error) {
+			if len(params) != 3 {
+				return nil, fmt.Errorf("expected three parameters, got %d", len(params))
+			}
+			s, sep, n, err := splitParams(params)
+			if err != nil {
+				return nil, err
+			}
+			if n == 0 {
+				return []string{}, nil
+			}
+			return strings.SplitN(s, sep, n), nil
+		},
+			new(func(string, string, int) ([]string, error)),
+		),
+		expr.Function("splitAfter", func(params ...any) (any, error) {
+			if len(params) < 2 || len(params) > 3 {
+				return nil, fmt.Errorf("expected two or three parameters, got %d", len(params))
+			}
+			s, sep, n, err := splitParams(params)
+			if err != nil {
+				return nil, err
+			}
+			if n == 0 {
+				return []string{}, nil
+			}
+			return strings.SplitAfterN(s, sep, n), nil
+		},
+			new(func(string, string) ([]string, error)),
+			new(func(string, string, int) ([]string, error)),
+		),
+	)
+}
+
+// splitParams returns the string, separator, and optional limit for the split functions. The limit defaults to -1,
+// meaning all substrings.
+func splitParams(params []any) (string, string, int, error) {
+	s, err := arg[string](params, 0)
+	if err != nil {
+		return "", "", 0, err
+	}
+	sep, err := arg[string](params, 1)
+	if err != nil {
+		return "", "", 0, err
+	}
+	n := -1
+	if len(params) == 3 {
+		if n, err = arg[int](params, 2); err != nil {
+			return "", "", 0, err
+		}
+	}
+	return s, sep, n, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitVariants(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want []string
+	}{
+		{
+			name: "splitN - limited",
+			exp:  `splitN("registry.com/org/image:v1", "/", 2)`,
+			want: []string{"registry.com", "org/image:v1"},
+		},
+		{
+			name: "splitN - limit larger than pieces",
+			exp:  `splitN("a,b", ",", 5)`,
+			want: []string{"a", "b"},
+		},
+		{
+			name: "splitN - negative limit",
+			exp:  `splitN("a,b,c", ",", -1)`,
+			want: []string{"a", "b", "c"},
+		},
+		{
+			name: "splitN - zero limit",
+			exp:  `splitN("a,b,c", ",", 0)`,
+			want: []string{},
+		},
+		{
+			name: "splitAfter - keeps separator",
+			exp:  `splitAfter("a, b, c", ", ")`,
+			want: []string{"a, ", "b, ", "c"},
+		},
+		{
+			name: "splitAfter - trailing separator",
+			exp:  `splitAfter("line1\nline2\n", "\n")`,
+			want: []string{"line1\n", "line2\n", ""},
+		},
+		{
+			name: "splitAfter - limited",
+			exp:  `splitAfter("a.b.c", ".", 2)`,
+			want: []string{"a.", "b.c"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Disable the builtins to make sure our splitAfter is the one being exercised.
+			program, err := expr.Compile(tc.exp, expr.Env(nil), expr.DisableAllBuiltins(), SplitVariants())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/expr-lang/expr"
+)
+
+// ansiEscape matches ANSI escape sequences: CSI sequences (colors, cursor movement, erasing), OSC sequences (window
+// titles, hyperlinks) terminated by BEL or ST, and the remaining two character escapes.
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// StripANSI provides the stripAnsi function as an Expr function. It removes ANSI escape sequences, such as color and
+// cursor movement codes, from a string. This is useful for cleaning up captured terminal output before evaluating it.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.StripANSI())
+//
+// Expression:
+//
+//	stripAnsi("\u001b[31mred\u001b[0m") // "red"
+func StripANSI() expr.Option {
+	return expr.Function("stripAnsi", func(params ...any) (any, error) {
+		if len(params) != 1 {
+			return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+		}
+		s, err := arg[string](params, 0)
+		if err != nil {
+			return nil, err
+		}
+		return ansiEscape.ReplaceAllString(s, ""), nil
+	},
+		new(func(string) string),
+	)
+}
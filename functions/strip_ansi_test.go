@@ -0,0 +1,64 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "colorized",
+			in:   "\x1b[1;31mERROR\x1b[0m: \x1b[38;5;208mdisk\x1b[39m full",
+			want: "ERROR: disk full",
+		},
+		{
+			name: "cursor movement",
+			in:   "progress\x1b[2K\x1b[1G50%\x1b[3A\x1b[?25l",
+			want: "progress50%",
+		},
+		{
+			name: "hyperlink",
+			in:   "\x1b]8;;https://example.com\x07link\x1b]8;;\x1b\\",
+			want: "link",
+		},
+		{
+			name: "plain text",
+			in:   "nothing [to] strip",
+			want: "nothing [to] strip",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			env := map[string]any{"s": tc.in}
+			program, err := expr.Compile(`stripAnsi(s)`, expr.Env(env), StripANSI())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
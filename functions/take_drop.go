@@ -0,0 +1,97 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+)
+
+// TakeDrop provides the takeWhile and dropWhile functions as Expr functions. takeWhile returns the leading elements
+// of a list for which a predicate holds, stopping at the first element that fails it. dropWhile returns the rest of
+// the list after that leading run.
+//
+// Expr reserves closure literals for its builtins, so the predicate is given as a string literal holding the closure
+// body, where # refers to the current element. The call is compiled into the findIndex and map builtins, so the
+// predicate can reference other variables.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.TakeDrop())
+//
+// Expression:
+//
+//	takeWhile([1, 2, 5, 1], "# < 3") // [1, 2]
+//	dropWhile([1, 2, 5, 1], "# < 3") // [5, 1]
+func TakeDrop() expr.Option {
+	return options(
+		expr.Function("takeWhile", func(params ...any) (any, error) {
+			return nil, closureError("predicate", params)
+		},
+			new(func([]any, string) ([]any, error)),
+			new(func([]int, string) ([]any, error)),
+			new(func([]float64, string) ([]any, error)),
+			new(func([]string, string) ([]any, error)),
+		),
+		expr.Function("dropWhile", func(params ...any) (any, error) {
+			return nil, closureError("predicate", params)
+		},
+			new(func([]any, string) ([]any, error)),
+			new(func([]int, string) ([]any, error)),
+			new(func([]float64, string) ([]any, error)),
+			new(func([]string, string) ([]any, error)),
+		),
+		expr.Patch(takeDropPatch{}),
+	)
+}
+
+// takeDropPatch rewrites takeWhile(list, "body") and dropWhile(list, "body") to find the length of the leading run,
+// findIndex(list, !(body)), or len(list) when every element is in the run, and then slice the list before or from it.
+// The list is evaluated once, and the slice is copied into a new []any with map.
+type takeDropPatch struct{}
+
+func (takeDropPatch) Visit(node *ast.Node) {
+	take := true
+	args, closure, ok := closureCall(*node, "takeWhile", 2)
+	if !ok {
+		take = false
+		if args, closure, ok = closureCall(*node, "dropWhile", 2); !ok {
+			return
+		}
+	}
+
+	ast.Patch(node, newLet("takeDrop#list", args[0], func(list func() ast.Node) ast.Node {
+		failed := &ast.BinaryNode{
+			Operator: "??",
+			Left: newBuiltin("findIndex", list(), &ast.ClosureNode{
+				Node: &ast.UnaryNode{Operator: "!", Node: closure.Node},
+			}),
+			Right: &ast.UnaryNode{Operator: "-", Node: &ast.IntegerNode{Value: 1}},
+		}
+		return newLet("takeDrop#failed", failed, func(failed func() ast.Node) ast.Node {
+			run := &ast.ConditionalNode{
+				Cond: &ast.BinaryNode{Operator: "<", Left: failed(), Right: &ast.IntegerNode{Value: 0}},
+				Exp1: newBuiltin("len", list()),
+				Exp2: failed(),
+			}
+			slice := &ast.SliceNode{Node: list(), To: run}
+			if !take {
+				slice = &ast.SliceNode{Node: list(), From: run}
+			}
+			return newBuiltin("map", slice, &ast.ClosureNode{Node: &ast.PointerNode{}})
+		})
+	}))
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTakeDrop(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           []any
+		wantCompileErr bool
+		wantRuntimeErr bool
+	}{
+		{
+			name: "take stops partway",
+			exp:  `takeWhile([1, 2, 5, 1], "# < 3")`,
+			want: []any{1, 2},
+		},
+		{
+			name: "drop stops partway",
+			exp:  `dropWhile([1, 2, 5, 1], "# < 3")`,
+			want: []any{5, 1},
+		},
+		{
+			name: "take matches all",
+			exp:  `takeWhile([1, 2, 3], "# > 0")`,
+			want: []any{1, 2, 3},
+		},
+		{
+			name: "drop matches all",
+			exp:  `dropWhile([1, 2, 3], "# > 0")`,
+			want: []any{},
+		},
+		{
+			name: "take matches none",
+			exp:  `takeWhile([1, 2, 3], "# > 5")`,
+			want: []any{},
+		},
+		{
+			name: "drop matches none",
+			exp:  `dropWhile([1, 2, 3], "# > 5")`,
+			want: []any{1, 2, 3},
+		},
+		{
+			name: "closure braces and member shorthand",
+			exp:  `takeWhile(events, "{.level == 'debug'}")`,
+			want: []any{map[string]any{"level": "debug"}},
+		},
+		{
+			name: "typed list from the environment",
+			exp:  `dropWhile(lines, "# startsWith '#'")`,
+			want: []any{"body", "# trailing"},
+		},
+		{
+			name: "elements after the run are not evaluated",
+			exp:  `takeWhile([1, "two"], "# > 1")`,
+			want: []any{},
+		},
+		{
+			name:           "invalid predicate",
+			exp:            `takeWhile([1, 2], "# <")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "non-boolean predicate",
+			exp:            `takeWhile([1, 2], "# + 1")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "non-boolean predicate on a typed list",
+			exp:            `takeWhile(lines, "len(#)")`,
+			wantCompileErr: true,
+		},
+		{
+			name: "predicate references a variable",
+			exp:  `takeWhile([1, 2, 5, 1], "# < limit")`,
+			want: []any{1, 2},
+		},
+		{
+			name: "predicate references a let variable",
+			exp:  `let n = 2; dropWhile([1, 2, 5, 1], "# <= n")`,
+			want: []any{5, 1},
+		},
+		{
+			name:           "predicate is not a literal",
+			exp:            `takeWhile([1, 2], "# > " + "0")`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{
+		"events": []any{map[string]any{"level": "debug"}, map[string]any{"level": "info"}},
+		"lines":  []string{"# header", "# comment", "body", "# trailing"},
+		"limit":  3,
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), TakeDrop())
+			if tc.wantCompileErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
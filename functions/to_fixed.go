@@ -0,0 +1,106 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/expr-lang/expr"
+)
+
+// maxFixedPlaces matches the upper bound JavaScript places on Number.prototype.toFixed.
+const maxFixedPlaces = 100
+
+// ToFixed provides the toFixed function as an Expr function. It formats a number as a string with exactly the given
+// number of decimal places, padding with trailing zeros as needed, like JavaScript's Number.prototype.toFixed. Unlike
+// round, which returns a number, the result is a string and so is free of float display artifacts.
+//
+// Rounding is applied to the exact binary value of the float, with ties rounded away from zero. As in JavaScript this
+// means toFixed(1.005, 2) is "1.00", because 1.005 is stored as 1.00499999999999989...
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.ToFixed())
+//
+// Expression:
+//
+//	toFixed(3.14159, 2) // "3.14"
+//	toFixed(2, 3)       // "2.000"
+//	toFixed(2.5, 0)     // "3"
+func ToFixed() expr.Option {
+	return expr.Function("toFixed", func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		x, err := argFloat(params, 0)
+		if err != nil {
+			return nil, err
+		}
+		places, err := arg[int](params, 1)
+		if err != nil {
+			return nil, err
+		}
+		return toFixed(x, places)
+	},
+		new(func(float64, int) (string, error)),
+	)
+}
+
+// toFixed formats x with exactly places decimal digits.
+func toFixed(x float64, places int) (string, error) {
+	if places < 0 || places > maxFixedPlaces {
+		return "", fmt.Errorf("places must be between 0 and %d, got %d", maxFixedPlaces, places)
+	}
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return "", fmt.Errorf("%v is not a finite number", x)
+	}
+
+	neg := x < 0
+	// A float64 has at most 1074 digits after the decimal point, so this is the exact decimal value of x.
+	exact := new(big.Float).SetFloat64(math.Abs(x)).Text('f', 1100)
+	intPart, fracPart, _ := strings.Cut(exact, ".")
+
+	digits := []byte(intPart + fracPart[:places])
+	if fracPart[places] >= '5' {
+		digits = incrementDecimal(digits)
+	}
+
+	var b strings.Builder
+	if neg {
+		b.WriteByte('-')
+	}
+	b.Write(digits[:len(digits)-places])
+	if places > 0 {
+		b.WriteByte('.')
+		b.Write(digits[len(digits)-places:])
+	}
+	return b.String(), nil
+}
+
+// incrementDecimal adds one to the decimal number held in digits, growing it if the carry overflows.
+func incrementDecimal(digits []byte) []byte {
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] < '9' {
+			digits[i]++
+			return digits
+		}
+		digits[i] = '0'
+	}
+	return append([]byte{'1'}, digits...)
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToFixed(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "truncates",
+			exp:  `toFixed(3.14159, 2)`,
+			want: "3.14",
+		},
+		{
+			name: "trailing zero padding",
+			exp:  `toFixed(1.5, 4)`,
+			want: "1.5000",
+		},
+		{
+			name: "integer padding",
+			exp:  `toFixed(2, 3)`,
+			want: "2.000",
+		},
+		{
+			name: "zero places",
+			exp:  `toFixed(1234.4, 0)`,
+			want: "1234",
+		},
+		{
+			name: "rounds half away from zero",
+			exp:  `[toFixed(2.5, 0), toFixed(-2.5, 0), toFixed(0.125, 2)]`,
+			want: []any{"3", "-3", "0.13"},
+		},
+		{
+			name: "rounding at the boundary uses the exact binary value",
+			exp:  `toFixed(1.005, 2)`,
+			want: "1.00",
+		},
+		{
+			name: "carry",
+			exp:  `toFixed(9.999, 2)`,
+			want: "10.00",
+		},
+		{
+			name: "avoids float artifacts",
+			exp:  `toFixed(0.1 + 0.2, 2)`,
+			want: "0.30",
+		},
+		{
+			name: "negative",
+			exp:  `toFixed(-1.25, 1)`,
+			want: "-1.3",
+		},
+		{
+			name:           "negative places",
+			exp:            `toFixed(1.5, -1)`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(nil), ToFixed())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, nil)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
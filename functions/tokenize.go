@@ -0,0 +1,81 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/expr-lang/expr"
+)
+
+// Tokenize provides the tokenize function as an Expr function. It splits a string into tokens. With a single
+// argument the string is split on whitespace and punctuation. With a second argument the tokens are every
+// non-overlapping match of the provided regular expression. Empty matches, which patterns such as "[0-9]*" produce
+// between tokens, are dropped.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Tokenize())
+//
+// Expression:
+//
+//	tokenize("Hello, world!")          // ["Hello", "world"]
+//	tokenize("a1 b22 c333", "[0-9]+") // ["1", "22", "333"]
+func Tokenize() expr.Option {
+	return expr.Function("tokenize", func(params ...any) (any, error) {
+		if len(params) < 1 || len(params) > 2 {
+			return nil, fmt.Errorf("expected one or two parameters, got %d", len(params))
+		}
+		s, err := arg[string](params, 0)
+		if err != nil {
+			return nil, err
+		}
+		var pattern string
+		if len(params) == 2 {
+			if pattern, err = arg[string](params, 1); err != nil {
+				return nil, err
+			}
+		}
+		return tokenize(s, pattern)
+	},
+		new(func(string) ([]string, error)),
+		new(func(string, string) ([]string, error)),
+	)
+}
+
+// tokenize splits s into tokens. An empty pattern splits on whitespace and punctuation, otherwise the pattern is
+// compiled as a regular expression and every non-empty match is returned as a token.
+func tokenize(s, pattern string) ([]string, error) {
+	if pattern == "" {
+		return strings.FieldsFunc(s, func(r rune) bool {
+			return unicode.IsSpace(r) || unicode.IsPunct(r)
+		}), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	tokens := []string{}
+	for _, t := range re.FindAllString(s, -1) {
+		if t != "" {
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens, nil
+}
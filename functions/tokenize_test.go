@@ -0,0 +1,98 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           []string
+		wantCompileErr bool
+		wantRuntimeErr bool
+	}{
+		{
+			name: "default",
+			exp:  `tokenize("Hello, world! It's  a\ttest.")`,
+			want: []string{"Hello", "world", "It", "s", "a", "test"},
+		},
+		{
+			name: "default - empty",
+			exp:  `tokenize("")`,
+			want: []string{},
+		},
+		{
+			name: "custom word pattern",
+			exp:  `tokenize("GET /api/v1/users?id=42", "[A-Za-z0-9]+")`,
+			want: []string{"GET", "api", "v1", "users", "id", "42"},
+		},
+		{
+			name: "custom pattern - no matches",
+			exp:  `tokenize("abc", "[0-9]+")`,
+			want: []string{},
+		},
+		{
+			name: "custom pattern - empty matches are dropped",
+			exp:  `tokenize("a1 b22", "[0-9]*")`,
+			want: []string{"1", "22"},
+		},
+		{
+			name: "custom pattern - only empty matches",
+			exp:  `tokenize("abc", "x?")`,
+			want: []string{},
+		},
+		{
+			name:           "invalid pattern",
+			exp:            `tokenize("abc", "[a-")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "no argument",
+			exp:            `tokenize()`,
+			wantCompileErr: true,
+		},
+		{
+			name:           "wrong type",
+			exp:            `tokenize(1)`,
+			wantCompileErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(nil), Tokenize())
+			if tc.wantCompileErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, nil)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/expr-lang/expr"
+	"golang.org/x/text/unicode/norm"
+)
+
+// asciiReplacements holds the Latin letters that do not decompose into an ASCII base letter and a diacritic.
+var asciiReplacements = map[rune]string{
+	'Æ': "AE", 'æ': "ae",
+	'Œ': "OE", 'œ': "oe",
+	'Ø': "O", 'ø': "o",
+	'Đ': "D", 'đ': "d",
+	'Ð': "D", 'ð': "d",
+	'Ł': "L", 'ł': "l",
+	'Þ': "TH", 'þ': "th",
+	'ß': "ss",
+	'ı': "i",
+}
+
+// Transliterate provides the toASCII function as an Expr function. It converts a string to ASCII by decomposing
+// accented characters (Unicode NFD) and stripping the diacritics, along with a small table of Latin letters such as
+// "ß" and "ø" that have no decomposition. Characters without an ASCII equivalent are dropped, or replaced with the
+// optional placeholder.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Transliterate())
+//
+// Expression:
+//
+//	toASCII("Crème Brûlée")  // "Creme Brulee"
+//	toASCII("Straße")        // "Strasse"
+//	toASCII("naïve ☕", "?") // "naive ?"
+func Transliterate() expr.Option {
+	return expr.Function("toASCII", func(params ...any) (any, error) {
+		if len(params) < 1 || len(params) > 2 {
+			return nil, fmt.Errorf("expected one or two parameters, got %d", len(params))
+		}
+		s, err := arg[string](params, 0)
+		if err != nil {
+			return nil, err
+		}
+		var placeholder string
+		if len(params) == 2 {
+			if placeholder, err = arg[string](params, 1); err != nil {
+				return nil, err
+			}
+		}
+		return toASCII(s, placeholder), nil
+	},
+		new(func(string) string),
+		new(func(string, string) string),
+	)
+}
+
+// toASCII transliterates s to ASCII, replacing characters that have no ASCII equivalent with placeholder.
+func toASCII(s, placeholder string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		switch {
+		case r <= unicode.MaxASCII:
+			b.WriteRune(r)
+		case unicode.Is(unicode.Mn, r):
+			// Combining marks are the diacritics split off by the decomposition.
+		default:
+			if repl, ok := asciiReplacements[r]; ok {
+				b.WriteString(repl)
+				continue
+			}
+			b.WriteString(placeholder)
+		}
+	}
+	return b.String()
+}
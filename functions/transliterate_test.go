@@ -0,0 +1,68 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  string
+		want string
+	}{
+		{
+			name: "accented latin",
+			exp:  `toASCII("Crème Brûlée à São Paulo, Zürich")`,
+			want: "Creme Brulee a Sao Paulo, Zurich",
+		},
+		{
+			name: "letters without a decomposition",
+			exp:  `toASCII("Straße Øresund Łódź")`,
+			want: "Strasse Oresund Lodz",
+		},
+		{
+			name: "no mapping - dropped",
+			exp:  `toASCII("coffee ☕ time")`,
+			want: "coffee  time",
+		},
+		{
+			name: "no mapping - placeholder",
+			exp:  `toASCII("coffee ☕ time", "?")`,
+			want: "coffee ? time",
+		},
+		{
+			name: "ascii unchanged",
+			exp:  `toASCII("plain-text_123")`,
+			want: "plain-text_123",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(nil), Transliterate())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
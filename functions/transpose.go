@@ -0,0 +1,68 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// Transpose provides the transpose function as an Expr function. It swaps the rows and columns of a rectangular list
+// of lists. Every row must have the same length.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Transpose())
+//
+// Expression:
+//
+//	transpose([[1, 2, 3], [4, 5, 6]]) // [[1, 4], [2, 5], [3, 6]]
+func Transpose() expr.Option {
+	return expr.Function("transpose", func(params ...any) (any, error) {
+		if len(params) != 1 {
+			return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+		}
+		matrix, err := toList(params[0])
+		if err != nil {
+			return nil, err
+		}
+		rows := make([][]any, len(matrix))
+		for i, r := range matrix {
+			if rows[i], err = toList(r); err != nil {
+				return nil, fmt.Errorf("row %d: %w", i, err)
+			}
+			if len(rows[i]) != len(rows[0]) {
+				return nil, fmt.Errorf("row %d has %d elements, expected %d", i, len(rows[i]), len(rows[0]))
+			}
+		}
+		if len(rows) == 0 {
+			return []any{}, nil
+		}
+
+		out := make([]any, len(rows[0]))
+		for j := range out {
+			col := make([]any, len(rows))
+			for i, r := range rows {
+				col[i] = r[j]
+			}
+			out[j] = col
+		}
+		return out, nil
+	},
+		new(func([]any) ([]any, error)),
+	)
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranspose(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           []any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "2x3 becomes 3x2",
+			exp:  `transpose([[1, 2, 3], [4, 5, 6]])`,
+			want: []any{[]any{1, 4}, []any{2, 5}, []any{3, 6}},
+		},
+		{
+			name: "typed rows from the environment",
+			exp:  `transpose(table)`,
+			want: []any{[]any{"name", "alice"}, []any{"role", "admin"}},
+		},
+		{
+			name: "single row",
+			exp:  `transpose([[1, 2]])`,
+			want: []any{[]any{1}, []any{2}},
+		},
+		{
+			name: "empty input",
+			exp:  `transpose([])`,
+			want: []any{},
+		},
+		{
+			name: "empty rows",
+			exp:  `transpose([[], []])`,
+			want: []any{},
+		},
+		{
+			name:           "ragged rows",
+			exp:            `transpose([[1, 2, 3], [4, 5]])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "row is not a list",
+			exp:            `transpose([[1], 2])`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{"table": []any{[]string{"name", "role"}, []string{"alice", "admin"}}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Transpose())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/expr-lang/expr"
+)
+
+// ParsedURL is the value returned by the url function. Its accessors are called like methods in an expression, such
+// as url(s).getScheme(), and are fields rather than methods so that they can have the lowercase names used there.
+// A ParsedURL marshals to JSON as the URL string.
+type ParsedURL struct {
+	// GetScheme returns the scheme, such as "https".
+	GetScheme func() string `expr:"getScheme" json:"-"`
+	// GetHost returns the host and port, if any, without the user info.
+	GetHost func() string `expr:"getHost" json:"-"`
+	// GetHostname returns the host name without the port. IPv6 addresses are returned without brackets.
+	GetHostname func() string `expr:"getHostname" json:"-"`
+	// GetPort returns the port, or "" when the URL has none.
+	GetPort func() string `expr:"getPort" json:"-"`
+	// GetEscapedPath returns the path in its escaped form, such as "/a%20b".
+	GetEscapedPath func() string `expr:"getEscapedPath" json:"-"`
+	// GetQuery returns the query parameters, mapping each name to the list of its values.
+	GetQuery func() map[string]any `expr:"getQuery" json:"-"`
+	// GetFragment returns the fragment, without the leading "#".
+	GetFragment func() string `expr:"getFragment" json:"-"`
+
+	u *url.URL
+}
+
+// MarshalJSON encodes the URL as a string.
+func (p ParsedURL) MarshalJSON() ([]byte, error) {
+	if p.u == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(p.u.String())
+}
+
+// URL provides the url and isURL functions as Expr functions. url parses a string into a ParsedURL, whose accessors
+// return the parts of the URL, and is an error when the string is not an absolute URL, with both a scheme and a host.
+// isURL reports whether url would accept a string.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.URL())
+//
+// Expression:
+//
+//	url("https://example.com:8080/a?b=c").getScheme() // "https"
+//	url("https://example.com:8080/a?b=c").getPort()   // "8080"
+//	url("https://example.com:8080/a?b=c").getQuery()  // {"b": ["c"]}
+//	isURL("https://example.com")                      // true
+//	isURL("/relative/path")                           // false
+func URL() expr.Option {
+	return options(
+		expr.Function("url", func(params ...any) (any, error) {
+			if len(params) != 1 {
+				return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+			}
+			s, err := arg[string](params, 0)
+			if err != nil {
+				return nil, err
+			}
+			u, err := parseAbsoluteURL(s)
+			if err != nil {
+				return nil, err
+			}
+			return newParsedURL(u), nil
+		},
+			new(func(string) (ParsedURL, error)),
+		),
+		expr.Function("isURL", func(params ...any) (any, error) {
+			if len(params) != 1 {
+				return nil, fmt.Errorf("expected one parameter, got %d", len(params))
+			}
+			s, err := arg[string](params, 0)
+			if err != nil {
+				return nil, err
+			}
+			_, err = parseAbsoluteURL(s)
+			return err == nil, nil
+		},
+			new(func(string) (bool, error)),
+		),
+	)
+}
+
+// parseAbsoluteURL parses s, returning an error unless it is an absolute URL with both a scheme and a host.
+func parseAbsoluteURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %w", s, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid URL %q: want an absolute URL with a scheme and a host", s)
+	}
+	return u, nil
+}
+
+func newParsedURL(u *url.URL) ParsedURL {
+	return ParsedURL{
+		GetScheme:      func() string { return u.Scheme },
+		GetHost:        func() string { return u.Host },
+		GetHostname:    u.Hostname,
+		GetPort:        u.Port,
+		GetEscapedPath: u.EscapedPath,
+		GetQuery: func() map[string]any {
+			out := make(map[string]any)
+			for k, vv := range u.Query() {
+				values := make([]any, len(vv))
+				for i, v := range vv {
+					values[i] = v
+				}
+				out[k] = values
+			}
+			return out
+		},
+		GetFragment: func() string { return u.Fragment },
+		u:           u,
+	}
+}
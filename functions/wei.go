@@ -0,0 +1,147 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/expr-lang/expr"
+)
+
+// weiDecimals is the number of decimal places between wei and each Ethereum unit.
+var weiDecimals = map[string]int{
+	"wei":   0,
+	"gwei":  9,
+	"ether": 18,
+}
+
+// Wei provides the toWei and fromWei functions as Expr functions. toWei converts a decimal amount in the given unit
+// to a whole number of wei, and fromWei converts a whole number of wei to a decimal amount in the given unit. The
+// units are "wei", "gwei", and "ether". Amounts are decimal strings and are converted with big integer arithmetic, so
+// no precision is lost. An amount with more decimal places than a wei can represent is an error.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Wei())
+//
+// Expression:
+//
+//	toWei("1.5", "ether")                   // "1500000000000000000"
+//	fromWei("1500000000000000000", "ether") // "1.5"
+//	fromWei("21000", "gwei")                // "0.000021"
+func Wei() expr.Option {
+	return options(
+		expr.Function("toWei", func(params ...any) (any, error) {
+			amount, decimals, err := weiParams(params)
+			if err != nil {
+				return nil, err
+			}
+			n, err := parseDecimal(amount, decimals)
+			if err != nil {
+				return nil, err
+			}
+			return n.String(), nil
+		},
+			new(func(string, string) (string, error)),
+		),
+		expr.Function("fromWei", func(params ...any) (any, error) {
+			amount, decimals, err := weiParams(params)
+			if err != nil {
+				return nil, err
+			}
+			n, err := parseDecimal(amount, 0)
+			if err != nil {
+				return nil, err
+			}
+			return formatDecimal(n, decimals), nil
+		},
+			new(func(string, string) (string, error)),
+		),
+	)
+}
+
+// weiParams returns the amount and the number of decimals for the unit in params.
+func weiParams(params []any) (string, int, error) {
+	if len(params) != 2 {
+		return "", 0, fmt.Errorf("expected two parameters, got %d", len(params))
+	}
+	amount, err := arg[string](params, 0)
+	if err != nil {
+		return "", 0, err
+	}
+	unit, err := arg[string](params, 1)
+	if err != nil {
+		return "", 0, err
+	}
+	decimals, ok := weiDecimals[strings.ToLower(unit)]
+	if !ok {
+		return "", 0, fmt.Errorf("unknown unit %q, expected wei, gwei, or ether", unit)
+	}
+	return amount, decimals, nil
+}
+
+// parseDecimal parses the decimal string s and returns it multiplied by 10^decimals, a number of wei. It is an error
+// for s to have more than decimals digits after the decimal point, which with no decimals means s is not whole.
+func parseDecimal(s string, decimals int) (*big.Int, error) {
+	digits := strings.TrimPrefix(s, "-")
+	whole, frac, _ := strings.Cut(digits, ".")
+	if (whole == "" && frac == "") || !isDigits(whole) || !isDigits(frac) {
+		return nil, fmt.Errorf("%q is not a decimal number", s)
+	}
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > decimals {
+		if decimals == 0 {
+			return nil, fmt.Errorf("wei amount %q must be a whole number", s)
+		}
+		return nil, fmt.Errorf("%q has more than %d decimal places", s, decimals)
+	}
+
+	n, _ := new(big.Int).SetString(whole+frac+strings.Repeat("0", decimals-len(frac)), 10)
+	if strings.HasPrefix(s, "-") {
+		n.Neg(n)
+	}
+	return n, nil
+}
+
+// formatDecimal returns n divided by 10^decimals as a decimal string, without trailing zeros after the point.
+func formatDecimal(n *big.Int, decimals int) string {
+	digits := new(big.Int).Abs(n).String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	whole, frac := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+
+	out := whole
+	if frac != "" {
+		out += "." + frac
+	}
+	if n.Sign() < 0 {
+		out = "-" + out
+	}
+	return out
+}
+
+// isDigits reports whether s consists only of ASCII digits. The empty string is considered digits.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWei(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           any
+		wantRuntimeErr bool
+		wantErrMsg     string
+	}{
+		{
+			name: "ether to wei",
+			exp:  `toWei("1.5", "ether")`,
+			want: "1500000000000000000",
+		},
+		{
+			name: "wei to ether",
+			exp:  `fromWei("1500000000000000000", "ether")`,
+			want: "1.5",
+		},
+		{
+			name: "ether round trip",
+			exp:  `fromWei(toWei("123456789.123456789012345678", "ether"), "ether")`,
+			want: "123456789.123456789012345678",
+		},
+		{
+			name: "gwei to wei",
+			exp:  `toWei("30", "gwei")`,
+			want: "30000000000",
+		},
+		{
+			name: "wei to gwei",
+			exp:  `fromWei("21000", "gwei")`,
+			want: "0.000021",
+		},
+		{
+			name: "whole result has no decimal point",
+			exp:  `fromWei("2000000000000000000", "ether")`,
+			want: "2",
+		},
+		{
+			name: "unit is case insensitive",
+			exp:  `toWei("1", "Gwei")`,
+			want: "1000000000",
+		},
+		{
+			name: "negative amount",
+			exp:  `fromWei("-500000000", "gwei")`,
+			want: "-0.5",
+		},
+		{
+			name: "threshold policy",
+			exp:  `fromWei(balance, "ether") == "0.25"`,
+			want: true,
+		},
+		{
+			name:           "too many decimal places",
+			exp:            `toWei("1.5", "wei")`,
+			wantRuntimeErr: true,
+			wantErrMsg:     `wei amount "1.5" must be a whole number`,
+		},
+		{
+			name:           "fractional wei",
+			exp:            `fromWei("1.5", "ether")`,
+			wantRuntimeErr: true,
+			wantErrMsg:     `wei amount "1.5" must be a whole number`,
+		},
+		{
+			name:           "invalid unit",
+			exp:            `toWei("1", "finney")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "non-numeric amount",
+			exp:            `toWei("one", "ether")`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "empty amount",
+			exp:            `toWei("", "ether")`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{"balance": "250000000000000000"}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Wei())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				require.ErrorContains(t, err, tc.wantErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// WeightedAverage provides the weightedAverage function as an Expr function. It returns the mean of a list of
+// numbers where each value is weighted by the number at the same position in a list of weights. The lists must be
+// the same length and hold only finite numbers, weights must not be negative, and the weights must not sum to zero.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.WeightedAverage())
+//
+// Expression:
+//
+//	weightedAverage([90, 80], [3, 1]) // 87.5
+func WeightedAverage() expr.Option {
+	return expr.Function("weightedAverage", func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		values, err := toFiniteFloats(params[0])
+		if err != nil {
+			return nil, fmt.Errorf("values: %w", err)
+		}
+		weights, err := toFiniteFloats(params[1])
+		if err != nil {
+			return nil, fmt.Errorf("weights: %w", err)
+		}
+		return weightedAverage(values, weights)
+	},
+		new(func([]any, []any) (float64, error)),
+		new(func([]int, []any) (float64, error)),
+		new(func([]float64, []any) (float64, error)),
+	)
+}
+
+// weightedAverage returns sum(values[i] * weights[i]) / sum(weights).
+func weightedAverage(values, weights []float64) (float64, error) {
+	if len(values) != len(weights) {
+		return 0, fmt.Errorf("got %d values but %d weights", len(values), len(weights))
+	}
+
+	var sum, total float64
+	for i, w := range weights {
+		if w < 0 {
+			return 0, fmt.Errorf("weight %d is negative: %v", i, w)
+		}
+		sum += values[i] * w
+		total += w
+	}
+	if total == 0 {
+		return 0, fmt.Errorf("weights sum to zero")
+	}
+	return finite("weightedAverage", sum/total)
+}
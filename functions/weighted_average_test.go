@@ -0,0 +1,99 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeightedAverage(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           float64
+		wantRuntimeErr bool
+	}{
+		{
+			name: "weighted mean",
+			exp:  `weightedAverage([90, 80, 70], [0.5, 0.3, 0.2])`,
+			want: 83,
+		},
+		{
+			name: "integer weights",
+			exp:  `weightedAverage(scores, [3, 1])`,
+			want: 87.5,
+		},
+		{
+			name: "zero weight ignored",
+			exp:  `weightedAverage([1, 100], [1, 0])`,
+			want: 1,
+		},
+		{
+			name:           "mismatched lengths",
+			exp:            `weightedAverage([1, 2, 3], [1, 2])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "zero-sum weights",
+			exp:            `weightedAverage([1, 2], [0, 0])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "negative weight",
+			exp:            `weightedAverage([1, 2], [2, -1])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "NaN value",
+			exp:            `weightedAverage([1, 0 / 0], [1, 1])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "infinite weight",
+			exp:            `weightedAverage([1, 2], [1, 1 / 0])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "weighted sum overflows",
+			exp:            `weightedAverage([1e308, 1e308], [2, 2])`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "non-numeric value",
+			exp:            `weightedAverage(["a"], [1])`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{"scores": []int{90, 80}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), WeightedAverage())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tc.want, got, 1e-9)
+		})
+	}
+}
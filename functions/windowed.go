@@ -0,0 +1,64 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// Windowed provides the windowed function as an Expr function. It returns every run of size consecutive elements of
+// a list, advancing one element at a time. A size larger than the list produces an empty result.
+//
+// Usage:
+//
+//	// Inject into your environment.
+//	_, err := expr.Compile(`foo`, expr.Env(nil), functions.Windowed())
+//
+// Expression:
+//
+//	windowed([1, 2, 3, 4], 2) // [[1, 2], [2, 3], [3, 4]]
+//	windowed([1, 2], 3)       // []
+func Windowed() expr.Option {
+	return expr.Function("windowed", func(params ...any) (any, error) {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("expected two parameters, got %d", len(params))
+		}
+		list, err := toList(params[0])
+		if err != nil {
+			return nil, err
+		}
+		size, err := arg[int](params, 1)
+		if err != nil {
+			return nil, err
+		}
+		if size <= 0 {
+			return nil, fmt.Errorf("window size must be positive, got %d", size)
+		}
+		out := make([]any, 0, max(len(list)-size+1, 0))
+		for i := 0; i+size <= len(list); i++ {
+			window := make([]any, size)
+			copy(window, list[i:i+size])
+			out = append(out, window)
+		}
+		return out, nil
+	},
+		new(func([]any, int) ([]any, error)),
+		new(func([]int, int) ([]any, error)),
+		new(func([]float64, int) ([]any, error)),
+		new(func([]string, int) ([]any, error)),
+	)
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 Peter Olds <me@polds.dev>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindowed(t *testing.T) {
+	tests := []struct {
+		name           string
+		exp            string
+		want           any
+		wantRuntimeErr bool
+	}{
+		{
+			name: "size 2",
+			exp:  `windowed([1, 2, 3, 4], 2)`,
+			want: []any{[]any{1, 2}, []any{2, 3}, []any{3, 4}},
+		},
+		{
+			name: "size 3",
+			exp:  `windowed([1, 2, 3, 4], 3)`,
+			want: []any{[]any{1, 2, 3}, []any{2, 3, 4}},
+		},
+		{
+			name: "size equal to length",
+			exp:  `windowed(["a", "b"], 2)`,
+			want: []any{[]any{"a", "b"}},
+		},
+		{
+			name: "oversized window",
+			exp:  `windowed([1, 2], 3)`,
+			want: []any{},
+		},
+		{
+			name: "detect a pattern",
+			exp:  `any(windowed(events, 2), #[0] == "fail" && #[1] == "fail")`,
+			want: true,
+		},
+		{
+			name:           "zero size",
+			exp:            `windowed([1, 2], 0)`,
+			wantRuntimeErr: true,
+		},
+		{
+			name:           "negative size",
+			exp:            `windowed([1, 2], -1)`,
+			wantRuntimeErr: true,
+		},
+	}
+
+	env := map[string]any{"events": []string{"ok", "fail", "fail", "ok"}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := expr.Compile(tc.exp, expr.Env(env), Windowed())
+			require.NoError(t, err)
+
+			got, err := expr.Run(program, env)
+			if tc.wantRuntimeErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
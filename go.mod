@@ -6,13 +6,19 @@ require (
 	github.com/expr-lang/expr v1.16.4
 	github.com/google/go-cmp v0.6.0
 	github.com/stretchr/testify v1.9.0
+	golang.org/x/crypto v0.17.0
+	golang.org/x/text v0.14.0
 	gopkg.in/yaml.v3 v3.0.1
+	k8s.io/apimachinery v0.29.3
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/kr/pretty v0.3.1 // indirect
+	github.com/gogo/protobuf v1.3.2 // indirect
+	github.com/kr/text v0.2.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/rogpeppe/go-internal v1.11.0 // indirect
+	golang.org/x/sys v0.15.0 // indirect
 	gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c // indirect
+	gopkg.in/inf.v0 v0.9.1 // indirect
 )